@@ -0,0 +1,160 @@
+package analytics
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang-restapi/analyticspb"
+	"golang-restapi/middlewares"
+	"golang-restapi/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var servicePrefix = "/" + analyticspb.AnalyticsService_ServiceDesc.ServiceName + "/"
+
+// methodRoutes are the /pow routes the methods mirror; a method costs the
+// rate limit tokens of its route (middlewares.RouteCosts).
+var methodRoutes = map[string]string{
+	analyticspb.AnalyticsService_GetFeeds_FullMethodName:                  "/pow/feeds",
+	analyticspb.AnalyticsService_MostCommonWords_FullMethodName:           "/pow/most_common_words",
+	analyticspb.AnalyticsService_GetSentimentGrouped_FullMethodName:       "/pow/get_sentiment_grouped",
+	analyticspb.AnalyticsService_TopFeeds_FullMethodName:                  "/pow/top_feeds",
+	analyticspb.AnalyticsService_BiasDetection_FullMethodName:             "/pow/bias_detection",
+	analyticspb.AnalyticsService_CorrelationBetweenSources_FullMethodName: "/pow/correlation_between_sources_avg_compound",
+	analyticspb.AnalyticsService_WordCoOccurrences_FullMethodName:         "/pow/word_co_occurences",
+	analyticspb.AnalyticsService_PhraseFrequencyTrends_FullMethodName:     "/pow/phrase_frequency_trends",
+	analyticspb.AnalyticsService_OverallStatistics_FullMethodName:         "/pow/overall_statistics",
+}
+
+// feedsStreamCost multiplies the cost of GetFeeds, which streams every page
+// of /pow/feeds in one call.
+const feedsStreamCost = 5
+
+// methodCost returns the rate limit tokens of method.
+func methodCost(method string) int {
+	cost := middlewares.RouteCost(methodRoutes[method])
+	if method == analyticspb.AnalyticsService_GetFeeds_FullMethodName {
+		cost *= feedsStreamCost
+	}
+	return cost
+}
+
+// Auth guards the AnalyticsService like the /pow routes: a bearer token in
+// the authorization metadata or an API key in x-api-key, the scope of the
+// method (read:feeds for GetFeeds, read:analytics otherwise) and the rate
+// limits of the peer IP, before authentication, and of the caller. A nil
+// Limiter disables rate limiting. Other services (health) are left open.
+type Auth struct {
+	Authenticator *middlewares.Authenticator
+	Limiter       *middlewares.RateLimiter
+}
+
+func (a Auth) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	if err := a.check(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a Auth) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := a.check(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (a Auth) check(ctx context.Context, method string) error {
+	if !strings.HasPrefix(method, servicePrefix) {
+		return nil
+	}
+
+	ip := peerIP(ctx)
+	if a.Limiter != nil {
+		// limit by IP before authentication, so failed attempts count
+		res, err := a.Limiter.TakeIP(ctx, ip)
+		if err != nil {
+			slog.ErrorContext(ctx, "rpc rate limit error", slog.String("method", method), slog.Any("error", err))
+		} else if !res.Allowed {
+			return rateLimited(ctx, res)
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	id, aerr := a.Authenticator.Authenticate(ctx, firstValue(md, "authorization"),
+		firstValue(md, strings.ToLower(middlewares.APIKeyHeader)))
+	if aerr != nil {
+		if aerr.Err != nil {
+			slog.ErrorContext(ctx, "rpc auth error", slog.String("method", method), slog.Any("error", aerr.Err))
+		}
+		return authStatus(aerr)
+	}
+
+	scope := models.ScopeReadAnalytics
+	if method == analyticspb.AnalyticsService_GetFeeds_FullMethodName {
+		scope = models.ScopeReadFeeds
+	}
+	if !id.HasScope(scope) {
+		return status.Error(codes.PermissionDenied, "API key lacks the "+scope+" scope.")
+	}
+
+	if a.Limiter == nil {
+		return nil
+	}
+	res, err := a.Limiter.Take(ctx, id, ip, methodCost(method))
+	if err != nil {
+		// fail open, as the HTTP routes do
+		slog.ErrorContext(ctx, "rpc rate limit error", slog.String("method", method), slog.Any("error", err))
+		return nil
+	}
+	if !res.Allowed {
+		return rateLimited(ctx, res)
+	}
+	return nil
+}
+
+func rateLimited(ctx context.Context, res middlewares.RateLimitResult) error {
+	grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(res.Retry.Seconds()+0.999))))
+	return status.Error(codes.ResourceExhausted, "Rate limit exceeded.")
+}
+
+// authStatus maps the HTTP status of an AuthError to a gRPC code.
+func authStatus(aerr *middlewares.AuthError) error {
+	switch aerr.Status {
+	case http.StatusUnauthorized:
+		return status.Error(codes.Unauthenticated, aerr.Detail)
+	case http.StatusForbidden:
+		return status.Error(codes.PermissionDenied, aerr.Detail)
+	default:
+		return status.Error(codes.Internal, aerr.Detail)
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,162 @@
+package analytics
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"golang-restapi/analyticspb"
+	"golang-restapi/config"
+	"golang-restapi/middlewares"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSecret = "test-secret"
+
+// dialAuth serves an AnalyticsService without handlers behind Auth: a call
+// that passes the interceptors fails with Unimplemented.
+func dialAuth(t *testing.T, limiter *middlewares.RateLimiter) *grpc.ClientConn {
+	t.Helper()
+	cfg := config.Config{JWTSecret: testSecret, AuthClockSkew: time.Minute, AuthRolesClaim: "roles"}
+	auth := Auth{Authenticator: middlewares.NewAuthenticator(cfg, nil), Limiter: limiter}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(auth.UnaryInterceptor),
+		grpc.StreamInterceptor(auth.StreamInterceptor),
+	)
+	analyticspb.RegisterAnalyticsServiceServer(srv, analyticspb.UnimplementedAnalyticsServiceServer{})
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func bearer(t *testing.T, secret string, exp time.Time) context.Context {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"email": "analyst@example.com",
+		"iss":   "test",
+		"exp":   exp.Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestAuthUnary(t *testing.T) {
+	client := analyticspb.NewAnalyticsServiceClient(dialAuth(t, nil))
+	req := &analyticspb.OverallStatisticsRequest{}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"no token", context.Background(), codes.Unauthenticated},
+		{"wrong secret", bearer(t, "other", time.Now().Add(time.Hour)), codes.Unauthenticated},
+		{"expired", bearer(t, testSecret, time.Now().Add(-time.Hour)), codes.Unauthenticated},
+		{"valid", bearer(t, testSecret, time.Now().Add(time.Hour)), codes.Unimplemented},
+	}
+	for _, tt := range tests {
+		_, err := client.OverallStatistics(tt.ctx, req)
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s: code %s, want %s (%v)", tt.name, got, tt.want, err)
+		}
+	}
+}
+
+func TestAuthStream(t *testing.T) {
+	client := analyticspb.NewAnalyticsServiceClient(dialAuth(t, nil))
+	for ctx, want := range map[context.Context]codes.Code{
+		context.Background():                             codes.Unauthenticated,
+		bearer(t, testSecret, time.Now().Add(time.Hour)): codes.Unimplemented,
+	} {
+		stream, err := client.GetFeeds(ctx, &analyticspb.GetFeedsRequest{})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if got := status.Code(err); got != want {
+			t.Errorf("GetFeeds: code %s, want %s (%v)", got, want, err)
+		}
+	}
+}
+
+func TestAuthLeavesHealthOpen(t *testing.T) {
+	client := healthpb.NewHealthClient(dialAuth(t, nil))
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("health check without a token: %v", err)
+	}
+}
+
+func TestAuthRateLimit(t *testing.T) {
+	limiter := middlewares.NewRateLimiter(middlewares.NewMemoryRateLimitStore(), 10)
+	client := analyticspb.NewAnalyticsServiceClient(dialAuth(t, limiter))
+	ctx := bearer(t, testSecret, time.Now().Add(time.Hour))
+
+	// PhraseFrequencyTrends costs the whole bucket
+	if _, err := client.PhraseFrequencyTrends(ctx, &analyticspb.PhraseFrequencyTrendsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("first call: %v", err)
+	}
+	var header metadata.MD
+	_, err := client.OverallStatistics(ctx, &analyticspb.OverallStatisticsRequest{}, grpc.Header(&header))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second call: %v, want ResourceExhausted", err)
+	}
+	if len(header.Get("retry-after")) == 0 {
+		t.Error("no retry-after metadata")
+	}
+}
+
+func TestAuthRateLimitsFailedAttempts(t *testing.T) {
+	limiter := middlewares.NewRateLimiter(middlewares.NewMemoryRateLimitStore(), 60)
+	limiter.IPCapacity = 2
+	client := analyticspb.NewAnalyticsServiceClient(dialAuth(t, limiter))
+	ctx := bearer(t, "wrong", time.Now().Add(time.Hour))
+
+	for i := range 2 {
+		if _, err := client.OverallStatistics(ctx, &analyticspb.OverallStatisticsRequest{}); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("attempt %d: %v, want Unauthenticated", i+1, err)
+		}
+	}
+	if _, err := client.OverallStatistics(ctx, &analyticspb.OverallStatisticsRequest{}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("attempt 3: %v, want ResourceExhausted", err)
+	}
+}
+
+func TestMethodCost(t *testing.T) {
+	for method, route := range methodRoutes {
+		if _, ok := middlewares.RouteCosts[route]; !ok {
+			t.Errorf("%s mirrors %s, which has no cost", method, route)
+		}
+	}
+	if got := methodCost(analyticspb.AnalyticsService_PhraseFrequencyTrends_FullMethodName); got != middlewares.RouteCosts["/pow/phrase_frequency_trends"] {
+		t.Errorf("PhraseFrequencyTrends costs %d, want the cost of its route", got)
+	}
+	if got, want := methodCost(analyticspb.AnalyticsService_GetFeeds_FullMethodName), middlewares.RouteCosts["/pow/feeds"]*feedsStreamCost; got != want {
+		t.Errorf("GetFeeds costs %d, want %d", got, want)
+	}
+	if got := methodCost("/grpc.health.v1.Health/Check"); got != 1 {
+		t.Errorf("unlisted method costs %d, want 1", got)
+	}
+}
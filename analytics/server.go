@@ -0,0 +1,396 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"golang-restapi/analyticspb"
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// Server implements analyticspb.AnalyticsServiceServer over the same
+// repositories as the /pow/* HTTP handlers, with the same defaults.
+type Server struct {
+	analyticspb.UnimplementedAnalyticsServiceServer
+}
+
+// NewServer returns an analytics gRPC service.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// GetFeeds streams every feed matching the filter, querying page_size rows at a time.
+func (s *Server) GetFeeds(req *analyticspb.GetFeedsRequest, stream analyticspb.AnalyticsService_GetFeedsServer) error {
+	if err := validateRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return err
+	}
+	lang, err := parseLang(req.GetLang())
+	if err != nil {
+		return err
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	ctx := stream.Context()
+	sources := intList(req.GetSources())
+	for page := 1; ; page++ {
+		resp, err := repositories.GetFeeds(ctx, req.GetStartDate(), req.GetEndDate(),
+			sources, req.GetFreeText(), lang, page, pageSize)
+		if err != nil {
+			return internal(ctx, err)
+		}
+		for _, f := range resp.Feeds {
+			if err := stream.Send(feedMessage(f)); err != nil {
+				return err
+			}
+		}
+		if len(resp.Feeds) < pageSize || page*pageSize >= resp.Total {
+			return nil
+		}
+	}
+}
+
+// MostCommonWords mirrors GET /pow/most_common_words.
+func (s *Server) MostCommonWords(ctx context.Context, req *analyticspb.MostCommonWordsRequest) (*analyticspb.MostCommonWordsResponse, error) {
+	if err := validateRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return nil, err
+	}
+	lang, err := parseLang(req.GetLang())
+	if err != nil {
+		return nil, err
+	}
+	norm, err := utils.NormalizerFor(req.GetNormalize(), lang)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	n := int(req.GetLimit())
+	if n <= 0 {
+		n = 20
+	}
+
+	words, err := repositories.MostCommonWords(ctx, req.GetStartDate(), req.GetEndDate(), lang, n, norm)
+	if err != nil {
+		return nil, internal(ctx, err)
+	}
+	resp := &analyticspb.MostCommonWordsResponse{Words: make([]*analyticspb.WordCount, 0, len(words))}
+	for _, w := range words {
+		resp.Words = append(resp.Words, &analyticspb.WordCount{Word: w.Word, Count: int32(w.Count)})
+	}
+	return resp, nil
+}
+
+// CountSentiments mirrors GET /pow/count_sentiments.
+func (s *Server) CountSentiments(ctx context.Context, req *analyticspb.DateRangeRequest) (*analyticspb.CountSentimentsResponse, error) {
+	if err := validateRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return nil, err
+	}
+	counts, err := repositories.CountSentiments(ctx, req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, internal(ctx, err)
+	}
+	return &analyticspb.CountSentimentsResponse{
+		Positive: int32(counts.Positive),
+		Negative: int32(counts.Negative),
+		Neutral:  int32(counts.Neutral),
+	}, nil
+}
+
+// GetSentimentGrouped mirrors GET /pow/get_sentiment_grouped.
+func (s *Server) GetSentimentGrouped(ctx context.Context, req *analyticspb.SentimentGroupedRequest) (*analyticspb.SentimentGroupedResponse, error) {
+	if err := validateRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return nil, err
+	}
+	groupBy := req.GetGroupBy()
+	if groupBy == "" {
+		groupBy = "source"
+	}
+
+	rows, err := repositories.GetSentimentGrouped(ctx, req.GetStartDate(), req.GetEndDate(), req.GetFreeText(), groupBy)
+	if err != nil {
+		return nil, internal(ctx, err)
+	}
+	series := utils.GenerateSentimentSeries(rows)
+	return &analyticspb.SentimentGroupedResponse{
+		Keys:     series.Keys,
+		Negative: int32List(series.Negative),
+		Neutral:  int32List(series.Neutral),
+		Positive: int32List(series.Positive),
+	}, nil
+}
+
+// TopFeeds mirrors GET /pow/top_feeds.
+func (s *Server) TopFeeds(ctx context.Context, req *analyticspb.TopFeedsRequest) (*analyticspb.TopFeedsResponse, error) {
+	if err := validateRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return nil, err
+	}
+	posNeg := strings.ToLower(req.GetPosNeg())
+	switch posNeg {
+	case "positive", "negative", "neutral":
+		// ok
+	default:
+		posNeg = "positive"
+	}
+	limit := int(req.GetLimit())
+	if limit < 1 {
+		limit = 5
+	}
+
+	rows, err := repositories.TopFeeds(ctx, req.GetStartDate(), req.GetEndDate(), posNeg, limit)
+	if err != nil {
+		return nil, internal(ctx, err)
+	}
+	resp := &analyticspb.TopFeedsResponse{Feeds: make([]*analyticspb.TopFeed, 0, len(rows))}
+	for _, r := range rows {
+		resp.Feeds = append(resp.Feeds, &analyticspb.TopFeed{
+			Title:             r.Title,
+			Published:         timestamppb.New(r.Published),
+			SourceName:        r.SourceName,
+			SentimentValue:    r.SentimentValue,
+			SentimentCompound: r.SentimentCompound,
+		})
+	}
+	return resp, nil
+}
+
+// BiasDetection mirrors GET /pow/bias_detection.
+func (s *Server) BiasDetection(ctx context.Context, req *analyticspb.KeywordRequest) (*analyticspb.BiasDetectionResponse, error) {
+	lang, word, err := keywordParams(req, true)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := repositories.BiasDetection(ctx, req.GetStartDate(), req.GetEndDate(), lang, word, int(req.GetEntityId()))
+	if err != nil {
+		return nil, repoError(ctx, err)
+	}
+	resp := &analyticspb.BiasDetectionResponse{Rows: make([]*analyticspb.BiasDetectionRow, 0, len(rows))}
+	for _, r := range rows {
+		resp.Rows = append(resp.Rows, &analyticspb.BiasDetectionRow{
+			SourceName:        r.SourceName,
+			Keyword:           r.Keyword,
+			MentionCount:      int32(r.MentionCount),
+			NetSentimentScore: r.NetSentimentScore,
+			SentimentStdDev:   r.SentimentStdDev,
+		})
+	}
+	return resp, nil
+}
+
+// CorrelationBetweenSources mirrors GET /pow/correlation_between_sources_avg_compound.
+func (s *Server) CorrelationBetweenSources(ctx context.Context, req *analyticspb.KeywordRequest) (*analyticspb.CorrelationResponse, error) {
+	lang, word, err := keywordParams(req, true)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := repositories.CorrelationBetweenSourcesAvgCompound(ctx, req.GetStartDate(), req.GetEndDate(),
+		lang, word, int(req.GetEntityId()), intList(req.GetSources()))
+	if err != nil {
+		return nil, repoError(ctx, err)
+	}
+	resp := &analyticspb.CorrelationResponse{Rows: make([]*analyticspb.CorrelationRow, 0, len(rows))}
+	for _, r := range rows {
+		resp.Rows = append(resp.Rows, &analyticspb.CorrelationRow{
+			SourceName:  r.SourceName,
+			Month:       r.Month,
+			AvgCompound: r.AvgCompound,
+		})
+	}
+	return resp, nil
+}
+
+// WordCoOccurrences mirrors GET /pow/word_co_occurences.
+func (s *Server) WordCoOccurrences(ctx context.Context, req *analyticspb.KeywordRequest) (*analyticspb.WordCoOccurrencesResponse, error) {
+	lang, word, err := keywordParams(req, false)
+	if err != nil {
+		return nil, err
+	}
+	norm, err := utils.NormalizerFor(req.GetNormalize(), lang)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	rows, err := repositories.WordCoOccurrences(ctx, req.GetStartDate(), req.GetEndDate(),
+		lang, word, int(req.GetEntityId()), intList(req.GetSources()), norm)
+	if err != nil {
+		return nil, repoError(ctx, err)
+	}
+	resp := &analyticspb.WordCoOccurrencesResponse{Rows: make([]*analyticspb.WordCoOccurrenceRow, 0, len(rows))}
+	for _, r := range rows {
+		resp.Rows = append(resp.Rows, &analyticspb.WordCoOccurrenceRow{
+			CoWord:        r.CoWord,
+			CoOccurrence:  int32(r.CoOccurrence),
+			PositiveCount: int32(r.PositiveCount),
+			NegativeCount: int32(r.NegativeCount),
+			NeutralCount:  int32(r.NeutralCount),
+		})
+	}
+	return resp, nil
+}
+
+// PhraseFrequencyTrends mirrors GET /pow/phrase_frequency_trends.
+func (s *Server) PhraseFrequencyTrends(ctx context.Context, req *analyticspb.PhraseFrequencyTrendsRequest) (*analyticspb.PhraseFrequencyTrendsResponse, error) {
+	if err := validateRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return nil, err
+	}
+	lang, err := parseLang(req.GetLang())
+	if err != nil {
+		return nil, err
+	}
+	dateGroup := strings.ToLower(req.GetDateGroup())
+	if dateGroup == "" {
+		dateGroup = "month"
+	}
+	namesExcluded := true
+	if req.NamesExcluded != nil {
+		namesExcluded = req.GetNamesExcluded()
+	}
+
+	rows, err := repositories.PhraseFrequencyTrends(ctx, req.GetStartDate(), req.GetEndDate(),
+		dateGroup, lang, intList(req.GetSources()), namesExcluded)
+	if err != nil {
+		return nil, internal(ctx, err)
+	}
+	resp := &analyticspb.PhraseFrequencyTrendsResponse{Rows: make([]*analyticspb.PhraseFrequencyRow, 0, len(rows))}
+	for _, r := range rows {
+		resp.Rows = append(resp.Rows, &analyticspb.PhraseFrequencyRow{
+			Source:    r.Source,
+			Phrase:    r.Phrase,
+			Year:      int32(r.Year),
+			DateGroup: int32(r.DateGroup),
+			Frequency: int32(r.Frequency),
+			Rank:      int32(r.Ranked),
+		})
+	}
+	return resp, nil
+}
+
+// OverallStatistics mirrors GET /pow/overall_statistics.
+func (s *Server) OverallStatistics(ctx context.Context, _ *analyticspb.OverallStatisticsRequest) (*analyticspb.OverallStatisticsResponse, error) {
+	st, err := repositories.OverallStatistics(ctx)
+	if err != nil {
+		return nil, internal(ctx, err)
+	}
+	return &analyticspb.OverallStatisticsResponse{
+		FirstFeedDate:        st.FirstFeedDate,
+		LastFeedDate:         st.LastFeedDate,
+		TimeSpanDays:         int32(st.TimeSpanDays),
+		TotalFeeds:           int32(st.TotalFeeds),
+		TotalSources:         int32(st.TotalSources),
+		TotalPositive:        int32(st.TotalPositive),
+		TotalNegative:        int32(st.TotalNegative),
+		TotalNeutral:         int32(st.TotalNeutral),
+		PctPositive:          st.PctPositive,
+		PctNegative:          st.PctNegative,
+		PctNeutral:           st.PctNeutral,
+		AvgFeedsPerDay:       st.AvgFeedsPerDay,
+		MostActiveSourceName: st.MostActiveSourceName,
+	}, nil
+}
+
+// validateRange requires both dates in YYYY-MM-DD form.
+func validateRange(start, end string) error {
+	if start == "" || end == "" {
+		return status.Error(codes.InvalidArgument, "start_date and end_date are required (YYYY-MM-DD)")
+	}
+	if _, err := time.Parse(dateLayout, start); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid start_date format")
+	}
+	if _, err := time.Parse(dateLayout, end); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid end_date format")
+	}
+	return nil
+}
+
+func parseLang(lang string) (string, error) {
+	lang, err := utils.ParseLang(lang)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return lang, nil
+}
+
+// keywordParams validates a KeywordRequest; singleToken rejects multi-word
+// input the way the bias and correlation endpoints do.
+func keywordParams(req *analyticspb.KeywordRequest, singleToken bool) (lang, word string, err error) {
+	if err := validateRange(req.GetStartDate(), req.GetEndDate()); err != nil {
+		return "", "", err
+	}
+	word = strings.TrimSpace(req.GetWord())
+	if req.GetEntityId() <= 0 {
+		if word == "" {
+			return "", "", status.Error(codes.InvalidArgument, "word or entity_id is required")
+		}
+		if singleToken && len(strings.Fields(word)) != 1 {
+			return "", "", status.Error(codes.InvalidArgument, "word must be a single token")
+		}
+	}
+	lang, err = parseLang(req.GetLang())
+	return lang, word, err
+}
+
+// repoError maps repository errors to status codes.
+func repoError(ctx context.Context, err error) error {
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		return status.Error(codes.NotFound, "entity not found")
+	}
+	return internal(ctx, err)
+}
+
+// internal reports a cancelled call as such and anything else as Internal.
+// The error is logged, not returned: it may hold SQL.
+func internal(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	slog.ErrorContext(ctx, "rpc failed", slog.Any("error", err))
+	return status.Error(codes.Internal, "internal error")
+}
+
+func feedMessage(f models.FeedWithDetails) *analyticspb.Feed {
+	return &analyticspb.Feed{
+		Id:                int64(f.Feed.ID),
+		Title:             f.Feed.Title,
+		Link:              f.Feed.Link,
+		Words:             f.Feed.Words,
+		Published:         timestamppb.New(f.Feed.Published),
+		SourceId:          int32(f.Source.ID),
+		SourceName:        f.Source.Name,
+		SentimentKey:      f.FeedSentiment.SentimentKey,
+		SentimentValue:    float64(f.FeedSentiment.SentimentValue),
+		SentimentCompound: float64(f.FeedSentiment.SentimentCompound),
+	}
+}
+
+func intList(ids []int32) []int {
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, int(id))
+	}
+	return out
+}
+
+func int32List(vals []int) []int32 {
+	out := make([]int32, 0, len(vals))
+	for _, v := range vals {
+		out = append(out, int32(v))
+	}
+	return out
+}
@@ -0,0 +1,1913 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.32.1
+// source: analytics.proto
+
+package analyticspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetFeedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Sources       []int32                `protobuf:"varint,3,rep,packed,name=sources,proto3" json:"sources,omitempty"`
+	FreeText      string                 `protobuf:"bytes,4,opt,name=free_text,json=freeText,proto3" json:"free_text,omitempty"`
+	Lang          string                 `protobuf:"bytes,5,opt,name=lang,proto3" json:"lang,omitempty"`
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // rows fetched per query while streaming, default 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeedsRequest) Reset() {
+	*x = GetFeedsRequest{}
+	mi := &file_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeedsRequest) ProtoMessage() {}
+
+func (x *GetFeedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeedsRequest.ProtoReflect.Descriptor instead.
+func (*GetFeedsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetFeedsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetFeedsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetFeedsRequest) GetSources() []int32 {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *GetFeedsRequest) GetFreeText() string {
+	if x != nil {
+		return x.FreeText
+	}
+	return ""
+}
+
+func (x *GetFeedsRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GetFeedsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type Feed struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title             string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Link              string                 `protobuf:"bytes,3,opt,name=link,proto3" json:"link,omitempty"`
+	Words             []string               `protobuf:"bytes,4,rep,name=words,proto3" json:"words,omitempty"`
+	Published         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=published,proto3" json:"published,omitempty"`
+	SourceId          int32                  `protobuf:"varint,6,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	SourceName        string                 `protobuf:"bytes,7,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	SentimentKey      string                 `protobuf:"bytes,8,opt,name=sentiment_key,json=sentimentKey,proto3" json:"sentiment_key,omitempty"`
+	SentimentValue    float64                `protobuf:"fixed64,9,opt,name=sentiment_value,json=sentimentValue,proto3" json:"sentiment_value,omitempty"`
+	SentimentCompound float64                `protobuf:"fixed64,10,opt,name=sentiment_compound,json=sentimentCompound,proto3" json:"sentiment_compound,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Feed) Reset() {
+	*x = Feed{}
+	mi := &file_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed) ProtoMessage() {}
+
+func (x *Feed) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed.ProtoReflect.Descriptor instead.
+func (*Feed) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Feed) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Feed) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Feed) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Feed) GetWords() []string {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *Feed) GetPublished() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Published
+	}
+	return nil
+}
+
+func (x *Feed) GetSourceId() int32 {
+	if x != nil {
+		return x.SourceId
+	}
+	return 0
+}
+
+func (x *Feed) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *Feed) GetSentimentKey() string {
+	if x != nil {
+		return x.SentimentKey
+	}
+	return ""
+}
+
+func (x *Feed) GetSentimentValue() float64 {
+	if x != nil {
+		return x.SentimentValue
+	}
+	return 0
+}
+
+func (x *Feed) GetSentimentCompound() float64 {
+	if x != nil {
+		return x.SentimentCompound
+	}
+	return 0
+}
+
+type MostCommonWordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Lang          string                 `protobuf:"bytes,3,opt,name=lang,proto3" json:"lang,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`        // default 20
+	Normalize     string                 `protobuf:"bytes,5,opt,name=normalize,proto3" json:"normalize,omitempty"` // "none" | "stem" | "lemma"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MostCommonWordsRequest) Reset() {
+	*x = MostCommonWordsRequest{}
+	mi := &file_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MostCommonWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MostCommonWordsRequest) ProtoMessage() {}
+
+func (x *MostCommonWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MostCommonWordsRequest.ProtoReflect.Descriptor instead.
+func (*MostCommonWordsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MostCommonWordsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *MostCommonWordsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *MostCommonWordsRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *MostCommonWordsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *MostCommonWordsRequest) GetNormalize() string {
+	if x != nil {
+		return x.Normalize
+	}
+	return ""
+}
+
+type WordCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WordCount) Reset() {
+	*x = WordCount{}
+	mi := &file_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WordCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordCount) ProtoMessage() {}
+
+func (x *WordCount) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordCount.ProtoReflect.Descriptor instead.
+func (*WordCount) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WordCount) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *WordCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type MostCommonWordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Words         []*WordCount           `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MostCommonWordsResponse) Reset() {
+	*x = MostCommonWordsResponse{}
+	mi := &file_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MostCommonWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MostCommonWordsResponse) ProtoMessage() {}
+
+func (x *MostCommonWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MostCommonWordsResponse.ProtoReflect.Descriptor instead.
+func (*MostCommonWordsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MostCommonWordsResponse) GetWords() []*WordCount {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type DateRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DateRangeRequest) Reset() {
+	*x = DateRangeRequest{}
+	mi := &file_analytics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DateRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateRangeRequest) ProtoMessage() {}
+
+func (x *DateRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateRangeRequest.ProtoReflect.Descriptor instead.
+func (*DateRangeRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DateRangeRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *DateRangeRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+type CountSentimentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Positive      int32                  `protobuf:"varint,1,opt,name=positive,proto3" json:"positive,omitempty"`
+	Negative      int32                  `protobuf:"varint,2,opt,name=negative,proto3" json:"negative,omitempty"`
+	Neutral       int32                  `protobuf:"varint,3,opt,name=neutral,proto3" json:"neutral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountSentimentsResponse) Reset() {
+	*x = CountSentimentsResponse{}
+	mi := &file_analytics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountSentimentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountSentimentsResponse) ProtoMessage() {}
+
+func (x *CountSentimentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountSentimentsResponse.ProtoReflect.Descriptor instead.
+func (*CountSentimentsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CountSentimentsResponse) GetPositive() int32 {
+	if x != nil {
+		return x.Positive
+	}
+	return 0
+}
+
+func (x *CountSentimentsResponse) GetNegative() int32 {
+	if x != nil {
+		return x.Negative
+	}
+	return 0
+}
+
+func (x *CountSentimentsResponse) GetNeutral() int32 {
+	if x != nil {
+		return x.Neutral
+	}
+	return 0
+}
+
+type SentimentGroupedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	FreeText      string                 `protobuf:"bytes,3,opt,name=free_text,json=freeText,proto3" json:"free_text,omitempty"`
+	GroupBy       string                 `protobuf:"bytes,4,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"` // "source" (default) or a date grouping
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SentimentGroupedRequest) Reset() {
+	*x = SentimentGroupedRequest{}
+	mi := &file_analytics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SentimentGroupedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SentimentGroupedRequest) ProtoMessage() {}
+
+func (x *SentimentGroupedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SentimentGroupedRequest.ProtoReflect.Descriptor instead.
+func (*SentimentGroupedRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SentimentGroupedRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *SentimentGroupedRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *SentimentGroupedRequest) GetFreeText() string {
+	if x != nil {
+		return x.FreeText
+	}
+	return ""
+}
+
+func (x *SentimentGroupedRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+// Three series aligned by keys, like /pow/get_sentiment_grouped.
+type SentimentGroupedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Negative      []int32                `protobuf:"varint,2,rep,packed,name=negative,proto3" json:"negative,omitempty"`
+	Neutral       []int32                `protobuf:"varint,3,rep,packed,name=neutral,proto3" json:"neutral,omitempty"`
+	Positive      []int32                `protobuf:"varint,4,rep,packed,name=positive,proto3" json:"positive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SentimentGroupedResponse) Reset() {
+	*x = SentimentGroupedResponse{}
+	mi := &file_analytics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SentimentGroupedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SentimentGroupedResponse) ProtoMessage() {}
+
+func (x *SentimentGroupedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SentimentGroupedResponse.ProtoReflect.Descriptor instead.
+func (*SentimentGroupedResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SentimentGroupedResponse) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *SentimentGroupedResponse) GetNegative() []int32 {
+	if x != nil {
+		return x.Negative
+	}
+	return nil
+}
+
+func (x *SentimentGroupedResponse) GetNeutral() []int32 {
+	if x != nil {
+		return x.Neutral
+	}
+	return nil
+}
+
+func (x *SentimentGroupedResponse) GetPositive() []int32 {
+	if x != nil {
+		return x.Positive
+	}
+	return nil
+}
+
+type TopFeedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	PosNeg        string                 `protobuf:"bytes,3,opt,name=pos_neg,json=posNeg,proto3" json:"pos_neg,omitempty"` // "positive" (default) | "negative" | "neutral"
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                // default 5
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopFeedsRequest) Reset() {
+	*x = TopFeedsRequest{}
+	mi := &file_analytics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopFeedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopFeedsRequest) ProtoMessage() {}
+
+func (x *TopFeedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopFeedsRequest.ProtoReflect.Descriptor instead.
+func (*TopFeedsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TopFeedsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *TopFeedsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *TopFeedsRequest) GetPosNeg() string {
+	if x != nil {
+		return x.PosNeg
+	}
+	return ""
+}
+
+func (x *TopFeedsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TopFeed struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Title             string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Published         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=published,proto3" json:"published,omitempty"`
+	SourceName        string                 `protobuf:"bytes,3,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	SentimentValue    float64                `protobuf:"fixed64,4,opt,name=sentiment_value,json=sentimentValue,proto3" json:"sentiment_value,omitempty"`
+	SentimentCompound float64                `protobuf:"fixed64,5,opt,name=sentiment_compound,json=sentimentCompound,proto3" json:"sentiment_compound,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TopFeed) Reset() {
+	*x = TopFeed{}
+	mi := &file_analytics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopFeed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopFeed) ProtoMessage() {}
+
+func (x *TopFeed) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopFeed.ProtoReflect.Descriptor instead.
+func (*TopFeed) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TopFeed) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TopFeed) GetPublished() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Published
+	}
+	return nil
+}
+
+func (x *TopFeed) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *TopFeed) GetSentimentValue() float64 {
+	if x != nil {
+		return x.SentimentValue
+	}
+	return 0
+}
+
+func (x *TopFeed) GetSentimentCompound() float64 {
+	if x != nil {
+		return x.SentimentCompound
+	}
+	return 0
+}
+
+type TopFeedsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feeds         []*TopFeed             `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopFeedsResponse) Reset() {
+	*x = TopFeedsResponse{}
+	mi := &file_analytics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopFeedsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopFeedsResponse) ProtoMessage() {}
+
+func (x *TopFeedsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopFeedsResponse.ProtoReflect.Descriptor instead.
+func (*TopFeedsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TopFeedsResponse) GetFeeds() []*TopFeed {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+// A single word or an entity id selects the feeds to analyze.
+type KeywordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Lang          string                 `protobuf:"bytes,3,opt,name=lang,proto3" json:"lang,omitempty"`
+	Word          string                 `protobuf:"bytes,4,opt,name=word,proto3" json:"word,omitempty"`
+	EntityId      int32                  `protobuf:"varint,5,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Sources       []int32                `protobuf:"varint,6,rep,packed,name=sources,proto3" json:"sources,omitempty"` // ignored by BiasDetection
+	Normalize     string                 `protobuf:"bytes,7,opt,name=normalize,proto3" json:"normalize,omitempty"`     // WordCoOccurrences only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeywordRequest) Reset() {
+	*x = KeywordRequest{}
+	mi := &file_analytics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeywordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeywordRequest) ProtoMessage() {}
+
+func (x *KeywordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeywordRequest.ProtoReflect.Descriptor instead.
+func (*KeywordRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *KeywordRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *KeywordRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *KeywordRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *KeywordRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *KeywordRequest) GetEntityId() int32 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *KeywordRequest) GetSources() []int32 {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *KeywordRequest) GetNormalize() string {
+	if x != nil {
+		return x.Normalize
+	}
+	return ""
+}
+
+type BiasDetectionRow struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SourceName        string                 `protobuf:"bytes,1,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	Keyword           string                 `protobuf:"bytes,2,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	MentionCount      int32                  `protobuf:"varint,3,opt,name=mention_count,json=mentionCount,proto3" json:"mention_count,omitempty"`
+	NetSentimentScore float64                `protobuf:"fixed64,4,opt,name=net_sentiment_score,json=netSentimentScore,proto3" json:"net_sentiment_score,omitempty"`
+	SentimentStdDev   float64                `protobuf:"fixed64,5,opt,name=sentiment_std_dev,json=sentimentStdDev,proto3" json:"sentiment_std_dev,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BiasDetectionRow) Reset() {
+	*x = BiasDetectionRow{}
+	mi := &file_analytics_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BiasDetectionRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BiasDetectionRow) ProtoMessage() {}
+
+func (x *BiasDetectionRow) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BiasDetectionRow.ProtoReflect.Descriptor instead.
+func (*BiasDetectionRow) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BiasDetectionRow) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *BiasDetectionRow) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *BiasDetectionRow) GetMentionCount() int32 {
+	if x != nil {
+		return x.MentionCount
+	}
+	return 0
+}
+
+func (x *BiasDetectionRow) GetNetSentimentScore() float64 {
+	if x != nil {
+		return x.NetSentimentScore
+	}
+	return 0
+}
+
+func (x *BiasDetectionRow) GetSentimentStdDev() float64 {
+	if x != nil {
+		return x.SentimentStdDev
+	}
+	return 0
+}
+
+type BiasDetectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*BiasDetectionRow    `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BiasDetectionResponse) Reset() {
+	*x = BiasDetectionResponse{}
+	mi := &file_analytics_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BiasDetectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BiasDetectionResponse) ProtoMessage() {}
+
+func (x *BiasDetectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BiasDetectionResponse.ProtoReflect.Descriptor instead.
+func (*BiasDetectionResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BiasDetectionResponse) GetRows() []*BiasDetectionRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type CorrelationRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceName    string                 `protobuf:"bytes,1,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	Month         string                 `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"`
+	AvgCompound   float64                `protobuf:"fixed64,3,opt,name=avg_compound,json=avgCompound,proto3" json:"avg_compound,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CorrelationRow) Reset() {
+	*x = CorrelationRow{}
+	mi := &file_analytics_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorrelationRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorrelationRow) ProtoMessage() {}
+
+func (x *CorrelationRow) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorrelationRow.ProtoReflect.Descriptor instead.
+func (*CorrelationRow) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CorrelationRow) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *CorrelationRow) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *CorrelationRow) GetAvgCompound() float64 {
+	if x != nil {
+		return x.AvgCompound
+	}
+	return 0
+}
+
+type CorrelationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*CorrelationRow      `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CorrelationResponse) Reset() {
+	*x = CorrelationResponse{}
+	mi := &file_analytics_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorrelationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorrelationResponse) ProtoMessage() {}
+
+func (x *CorrelationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorrelationResponse.ProtoReflect.Descriptor instead.
+func (*CorrelationResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CorrelationResponse) GetRows() []*CorrelationRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type WordCoOccurrenceRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CoWord        string                 `protobuf:"bytes,1,opt,name=co_word,json=coWord,proto3" json:"co_word,omitempty"`
+	CoOccurrence  int32                  `protobuf:"varint,2,opt,name=co_occurrence,json=coOccurrence,proto3" json:"co_occurrence,omitempty"`
+	PositiveCount int32                  `protobuf:"varint,3,opt,name=positive_count,json=positiveCount,proto3" json:"positive_count,omitempty"`
+	NegativeCount int32                  `protobuf:"varint,4,opt,name=negative_count,json=negativeCount,proto3" json:"negative_count,omitempty"`
+	NeutralCount  int32                  `protobuf:"varint,5,opt,name=neutral_count,json=neutralCount,proto3" json:"neutral_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WordCoOccurrenceRow) Reset() {
+	*x = WordCoOccurrenceRow{}
+	mi := &file_analytics_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WordCoOccurrenceRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordCoOccurrenceRow) ProtoMessage() {}
+
+func (x *WordCoOccurrenceRow) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordCoOccurrenceRow.ProtoReflect.Descriptor instead.
+func (*WordCoOccurrenceRow) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WordCoOccurrenceRow) GetCoWord() string {
+	if x != nil {
+		return x.CoWord
+	}
+	return ""
+}
+
+func (x *WordCoOccurrenceRow) GetCoOccurrence() int32 {
+	if x != nil {
+		return x.CoOccurrence
+	}
+	return 0
+}
+
+func (x *WordCoOccurrenceRow) GetPositiveCount() int32 {
+	if x != nil {
+		return x.PositiveCount
+	}
+	return 0
+}
+
+func (x *WordCoOccurrenceRow) GetNegativeCount() int32 {
+	if x != nil {
+		return x.NegativeCount
+	}
+	return 0
+}
+
+func (x *WordCoOccurrenceRow) GetNeutralCount() int32 {
+	if x != nil {
+		return x.NeutralCount
+	}
+	return 0
+}
+
+type WordCoOccurrencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*WordCoOccurrenceRow `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WordCoOccurrencesResponse) Reset() {
+	*x = WordCoOccurrencesResponse{}
+	mi := &file_analytics_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WordCoOccurrencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordCoOccurrencesResponse) ProtoMessage() {}
+
+func (x *WordCoOccurrencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordCoOccurrencesResponse.ProtoReflect.Descriptor instead.
+func (*WordCoOccurrencesResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WordCoOccurrencesResponse) GetRows() []*WordCoOccurrenceRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type PhraseFrequencyTrendsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	DateGroup     string                 `protobuf:"bytes,3,opt,name=date_group,json=dateGroup,proto3" json:"date_group,omitempty"` // default "month"
+	Lang          string                 `protobuf:"bytes,4,opt,name=lang,proto3" json:"lang,omitempty"`
+	Sources       []int32                `protobuf:"varint,5,rep,packed,name=sources,proto3" json:"sources,omitempty"`
+	NamesExcluded *bool                  `protobuf:"varint,6,opt,name=names_excluded,json=namesExcluded,proto3,oneof" json:"names_excluded,omitempty"` // default true
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhraseFrequencyTrendsRequest) Reset() {
+	*x = PhraseFrequencyTrendsRequest{}
+	mi := &file_analytics_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhraseFrequencyTrendsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhraseFrequencyTrendsRequest) ProtoMessage() {}
+
+func (x *PhraseFrequencyTrendsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhraseFrequencyTrendsRequest.ProtoReflect.Descriptor instead.
+func (*PhraseFrequencyTrendsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PhraseFrequencyTrendsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *PhraseFrequencyTrendsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *PhraseFrequencyTrendsRequest) GetDateGroup() string {
+	if x != nil {
+		return x.DateGroup
+	}
+	return ""
+}
+
+func (x *PhraseFrequencyTrendsRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *PhraseFrequencyTrendsRequest) GetSources() []int32 {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *PhraseFrequencyTrendsRequest) GetNamesExcluded() bool {
+	if x != nil && x.NamesExcluded != nil {
+		return *x.NamesExcluded
+	}
+	return false
+}
+
+type PhraseFrequencyRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Phrase        string                 `protobuf:"bytes,2,opt,name=phrase,proto3" json:"phrase,omitempty"`
+	Year          int32                  `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+	DateGroup     int32                  `protobuf:"varint,4,opt,name=date_group,json=dateGroup,proto3" json:"date_group,omitempty"`
+	Frequency     int32                  `protobuf:"varint,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Rank          int32                  `protobuf:"varint,6,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhraseFrequencyRow) Reset() {
+	*x = PhraseFrequencyRow{}
+	mi := &file_analytics_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhraseFrequencyRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhraseFrequencyRow) ProtoMessage() {}
+
+func (x *PhraseFrequencyRow) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhraseFrequencyRow.ProtoReflect.Descriptor instead.
+func (*PhraseFrequencyRow) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PhraseFrequencyRow) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PhraseFrequencyRow) GetPhrase() string {
+	if x != nil {
+		return x.Phrase
+	}
+	return ""
+}
+
+func (x *PhraseFrequencyRow) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *PhraseFrequencyRow) GetDateGroup() int32 {
+	if x != nil {
+		return x.DateGroup
+	}
+	return 0
+}
+
+func (x *PhraseFrequencyRow) GetFrequency() int32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *PhraseFrequencyRow) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type PhraseFrequencyTrendsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*PhraseFrequencyRow  `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhraseFrequencyTrendsResponse) Reset() {
+	*x = PhraseFrequencyTrendsResponse{}
+	mi := &file_analytics_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhraseFrequencyTrendsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhraseFrequencyTrendsResponse) ProtoMessage() {}
+
+func (x *PhraseFrequencyTrendsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhraseFrequencyTrendsResponse.ProtoReflect.Descriptor instead.
+func (*PhraseFrequencyTrendsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PhraseFrequencyTrendsResponse) GetRows() []*PhraseFrequencyRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type OverallStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverallStatisticsRequest) Reset() {
+	*x = OverallStatisticsRequest{}
+	mi := &file_analytics_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverallStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverallStatisticsRequest) ProtoMessage() {}
+
+func (x *OverallStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverallStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*OverallStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{22}
+}
+
+type OverallStatisticsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	FirstFeedDate        string                 `protobuf:"bytes,1,opt,name=first_feed_date,json=firstFeedDate,proto3" json:"first_feed_date,omitempty"`
+	LastFeedDate         string                 `protobuf:"bytes,2,opt,name=last_feed_date,json=lastFeedDate,proto3" json:"last_feed_date,omitempty"`
+	TimeSpanDays         int32                  `protobuf:"varint,3,opt,name=time_span_days,json=timeSpanDays,proto3" json:"time_span_days,omitempty"`
+	TotalFeeds           int32                  `protobuf:"varint,4,opt,name=total_feeds,json=totalFeeds,proto3" json:"total_feeds,omitempty"`
+	TotalSources         int32                  `protobuf:"varint,5,opt,name=total_sources,json=totalSources,proto3" json:"total_sources,omitempty"`
+	TotalPositive        int32                  `protobuf:"varint,6,opt,name=total_positive,json=totalPositive,proto3" json:"total_positive,omitempty"`
+	TotalNegative        int32                  `protobuf:"varint,7,opt,name=total_negative,json=totalNegative,proto3" json:"total_negative,omitempty"`
+	TotalNeutral         int32                  `protobuf:"varint,8,opt,name=total_neutral,json=totalNeutral,proto3" json:"total_neutral,omitempty"`
+	PctPositive          float64                `protobuf:"fixed64,9,opt,name=pct_positive,json=pctPositive,proto3" json:"pct_positive,omitempty"`
+	PctNegative          float64                `protobuf:"fixed64,10,opt,name=pct_negative,json=pctNegative,proto3" json:"pct_negative,omitempty"`
+	PctNeutral           float64                `protobuf:"fixed64,11,opt,name=pct_neutral,json=pctNeutral,proto3" json:"pct_neutral,omitempty"`
+	AvgFeedsPerDay       float64                `protobuf:"fixed64,12,opt,name=avg_feeds_per_day,json=avgFeedsPerDay,proto3" json:"avg_feeds_per_day,omitempty"`
+	MostActiveSourceName string                 `protobuf:"bytes,13,opt,name=most_active_source_name,json=mostActiveSourceName,proto3" json:"most_active_source_name,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *OverallStatisticsResponse) Reset() {
+	*x = OverallStatisticsResponse{}
+	mi := &file_analytics_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverallStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverallStatisticsResponse) ProtoMessage() {}
+
+func (x *OverallStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverallStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*OverallStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OverallStatisticsResponse) GetFirstFeedDate() string {
+	if x != nil {
+		return x.FirstFeedDate
+	}
+	return ""
+}
+
+func (x *OverallStatisticsResponse) GetLastFeedDate() string {
+	if x != nil {
+		return x.LastFeedDate
+	}
+	return ""
+}
+
+func (x *OverallStatisticsResponse) GetTimeSpanDays() int32 {
+	if x != nil {
+		return x.TimeSpanDays
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetTotalFeeds() int32 {
+	if x != nil {
+		return x.TotalFeeds
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetTotalSources() int32 {
+	if x != nil {
+		return x.TotalSources
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetTotalPositive() int32 {
+	if x != nil {
+		return x.TotalPositive
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetTotalNegative() int32 {
+	if x != nil {
+		return x.TotalNegative
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetTotalNeutral() int32 {
+	if x != nil {
+		return x.TotalNeutral
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetPctPositive() float64 {
+	if x != nil {
+		return x.PctPositive
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetPctNegative() float64 {
+	if x != nil {
+		return x.PctNegative
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetPctNeutral() float64 {
+	if x != nil {
+		return x.PctNeutral
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetAvgFeedsPerDay() float64 {
+	if x != nil {
+		return x.AvgFeedsPerDay
+	}
+	return 0
+}
+
+func (x *OverallStatisticsResponse) GetMostActiveSourceName() string {
+	if x != nil {
+		return x.MostActiveSourceName
+	}
+	return ""
+}
+
+var File_analytics_proto protoreflect.FileDescriptor
+
+const file_analytics_proto_rawDesc = "" +
+	"\n" +
+	"\x0fanalytics.proto\x12\tanalytics\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x01\n" +
+	"\x0fGetFeedsRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x18\n" +
+	"\asources\x18\x03 \x03(\x05R\asources\x12\x1b\n" +
+	"\tfree_text\x18\x04 \x01(\tR\bfreeText\x12\x12\n" +
+	"\x04lang\x18\x05 \x01(\tR\x04lang\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"\xcb\x02\n" +
+	"\x04Feed\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04link\x18\x03 \x01(\tR\x04link\x12\x14\n" +
+	"\x05words\x18\x04 \x03(\tR\x05words\x128\n" +
+	"\tpublished\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tpublished\x12\x1b\n" +
+	"\tsource_id\x18\x06 \x01(\x05R\bsourceId\x12\x1f\n" +
+	"\vsource_name\x18\a \x01(\tR\n" +
+	"sourceName\x12#\n" +
+	"\rsentiment_key\x18\b \x01(\tR\fsentimentKey\x12'\n" +
+	"\x0fsentiment_value\x18\t \x01(\x01R\x0esentimentValue\x12-\n" +
+	"\x12sentiment_compound\x18\n" +
+	" \x01(\x01R\x11sentimentCompound\"\x9a\x01\n" +
+	"\x16MostCommonWordsRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x12\n" +
+	"\x04lang\x18\x03 \x01(\tR\x04lang\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tnormalize\x18\x05 \x01(\tR\tnormalize\"5\n" +
+	"\tWordCount\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"E\n" +
+	"\x17MostCommonWordsResponse\x12*\n" +
+	"\x05words\x18\x01 \x03(\v2\x14.analytics.WordCountR\x05words\"L\n" +
+	"\x10DateRangeRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\"k\n" +
+	"\x17CountSentimentsResponse\x12\x1a\n" +
+	"\bpositive\x18\x01 \x01(\x05R\bpositive\x12\x1a\n" +
+	"\bnegative\x18\x02 \x01(\x05R\bnegative\x12\x18\n" +
+	"\aneutral\x18\x03 \x01(\x05R\aneutral\"\x8b\x01\n" +
+	"\x17SentimentGroupedRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x1b\n" +
+	"\tfree_text\x18\x03 \x01(\tR\bfreeText\x12\x19\n" +
+	"\bgroup_by\x18\x04 \x01(\tR\agroupBy\"\x80\x01\n" +
+	"\x18SentimentGroupedResponse\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\x12\x1a\n" +
+	"\bnegative\x18\x02 \x03(\x05R\bnegative\x12\x18\n" +
+	"\aneutral\x18\x03 \x03(\x05R\aneutral\x12\x1a\n" +
+	"\bpositive\x18\x04 \x03(\x05R\bpositive\"z\n" +
+	"\x0fTopFeedsRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x17\n" +
+	"\apos_neg\x18\x03 \x01(\tR\x06posNeg\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xd2\x01\n" +
+	"\aTopFeed\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x128\n" +
+	"\tpublished\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tpublished\x12\x1f\n" +
+	"\vsource_name\x18\x03 \x01(\tR\n" +
+	"sourceName\x12'\n" +
+	"\x0fsentiment_value\x18\x04 \x01(\x01R\x0esentimentValue\x12-\n" +
+	"\x12sentiment_compound\x18\x05 \x01(\x01R\x11sentimentCompound\"<\n" +
+	"\x10TopFeedsResponse\x12(\n" +
+	"\x05feeds\x18\x01 \x03(\v2\x12.analytics.TopFeedR\x05feeds\"\xc7\x01\n" +
+	"\x0eKeywordRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x12\n" +
+	"\x04lang\x18\x03 \x01(\tR\x04lang\x12\x12\n" +
+	"\x04word\x18\x04 \x01(\tR\x04word\x12\x1b\n" +
+	"\tentity_id\x18\x05 \x01(\x05R\bentityId\x12\x18\n" +
+	"\asources\x18\x06 \x03(\x05R\asources\x12\x1c\n" +
+	"\tnormalize\x18\a \x01(\tR\tnormalize\"\xce\x01\n" +
+	"\x10BiasDetectionRow\x12\x1f\n" +
+	"\vsource_name\x18\x01 \x01(\tR\n" +
+	"sourceName\x12\x18\n" +
+	"\akeyword\x18\x02 \x01(\tR\akeyword\x12#\n" +
+	"\rmention_count\x18\x03 \x01(\x05R\fmentionCount\x12.\n" +
+	"\x13net_sentiment_score\x18\x04 \x01(\x01R\x11netSentimentScore\x12*\n" +
+	"\x11sentiment_std_dev\x18\x05 \x01(\x01R\x0fsentimentStdDev\"H\n" +
+	"\x15BiasDetectionResponse\x12/\n" +
+	"\x04rows\x18\x01 \x03(\v2\x1b.analytics.BiasDetectionRowR\x04rows\"j\n" +
+	"\x0eCorrelationRow\x12\x1f\n" +
+	"\vsource_name\x18\x01 \x01(\tR\n" +
+	"sourceName\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\tR\x05month\x12!\n" +
+	"\favg_compound\x18\x03 \x01(\x01R\vavgCompound\"D\n" +
+	"\x13CorrelationResponse\x12-\n" +
+	"\x04rows\x18\x01 \x03(\v2\x19.analytics.CorrelationRowR\x04rows\"\xc6\x01\n" +
+	"\x13WordCoOccurrenceRow\x12\x17\n" +
+	"\aco_word\x18\x01 \x01(\tR\x06coWord\x12#\n" +
+	"\rco_occurrence\x18\x02 \x01(\x05R\fcoOccurrence\x12%\n" +
+	"\x0epositive_count\x18\x03 \x01(\x05R\rpositiveCount\x12%\n" +
+	"\x0enegative_count\x18\x04 \x01(\x05R\rnegativeCount\x12#\n" +
+	"\rneutral_count\x18\x05 \x01(\x05R\fneutralCount\"O\n" +
+	"\x19WordCoOccurrencesResponse\x122\n" +
+	"\x04rows\x18\x01 \x03(\v2\x1e.analytics.WordCoOccurrenceRowR\x04rows\"\xe4\x01\n" +
+	"\x1cPhraseFrequencyTrendsRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x1d\n" +
+	"\n" +
+	"date_group\x18\x03 \x01(\tR\tdateGroup\x12\x12\n" +
+	"\x04lang\x18\x04 \x01(\tR\x04lang\x12\x18\n" +
+	"\asources\x18\x05 \x03(\x05R\asources\x12*\n" +
+	"\x0enames_excluded\x18\x06 \x01(\bH\x00R\rnamesExcluded\x88\x01\x01B\x11\n" +
+	"\x0f_names_excluded\"\xa9\x01\n" +
+	"\x12PhraseFrequencyRow\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06phrase\x18\x02 \x01(\tR\x06phrase\x12\x12\n" +
+	"\x04year\x18\x03 \x01(\x05R\x04year\x12\x1d\n" +
+	"\n" +
+	"date_group\x18\x04 \x01(\x05R\tdateGroup\x12\x1c\n" +
+	"\tfrequency\x18\x05 \x01(\x05R\tfrequency\x12\x12\n" +
+	"\x04rank\x18\x06 \x01(\x05R\x04rank\"R\n" +
+	"\x1dPhraseFrequencyTrendsResponse\x121\n" +
+	"\x04rows\x18\x01 \x03(\v2\x1d.analytics.PhraseFrequencyRowR\x04rows\"\x1a\n" +
+	"\x18OverallStatisticsRequest\"\x91\x04\n" +
+	"\x19OverallStatisticsResponse\x12&\n" +
+	"\x0ffirst_feed_date\x18\x01 \x01(\tR\rfirstFeedDate\x12$\n" +
+	"\x0elast_feed_date\x18\x02 \x01(\tR\flastFeedDate\x12$\n" +
+	"\x0etime_span_days\x18\x03 \x01(\x05R\ftimeSpanDays\x12\x1f\n" +
+	"\vtotal_feeds\x18\x04 \x01(\x05R\n" +
+	"totalFeeds\x12#\n" +
+	"\rtotal_sources\x18\x05 \x01(\x05R\ftotalSources\x12%\n" +
+	"\x0etotal_positive\x18\x06 \x01(\x05R\rtotalPositive\x12%\n" +
+	"\x0etotal_negative\x18\a \x01(\x05R\rtotalNegative\x12#\n" +
+	"\rtotal_neutral\x18\b \x01(\x05R\ftotalNeutral\x12!\n" +
+	"\fpct_positive\x18\t \x01(\x01R\vpctPositive\x12!\n" +
+	"\fpct_negative\x18\n" +
+	" \x01(\x01R\vpctNegative\x12\x1f\n" +
+	"\vpct_neutral\x18\v \x01(\x01R\n" +
+	"pctNeutral\x12)\n" +
+	"\x11avg_feeds_per_day\x18\f \x01(\x01R\x0eavgFeedsPerDay\x125\n" +
+	"\x17most_active_source_name\x18\r \x01(\tR\x14mostActiveSourceName2\xe8\x06\n" +
+	"\x10AnalyticsService\x129\n" +
+	"\bGetFeeds\x12\x1a.analytics.GetFeedsRequest\x1a\x0f.analytics.Feed0\x01\x12X\n" +
+	"\x0fMostCommonWords\x12!.analytics.MostCommonWordsRequest\x1a\".analytics.MostCommonWordsResponse\x12R\n" +
+	"\x0fCountSentiments\x12\x1b.analytics.DateRangeRequest\x1a\".analytics.CountSentimentsResponse\x12^\n" +
+	"\x13GetSentimentGrouped\x12\".analytics.SentimentGroupedRequest\x1a#.analytics.SentimentGroupedResponse\x12C\n" +
+	"\bTopFeeds\x12\x1a.analytics.TopFeedsRequest\x1a\x1b.analytics.TopFeedsResponse\x12L\n" +
+	"\rBiasDetection\x12\x19.analytics.KeywordRequest\x1a .analytics.BiasDetectionResponse\x12V\n" +
+	"\x19CorrelationBetweenSources\x12\x19.analytics.KeywordRequest\x1a\x1e.analytics.CorrelationResponse\x12T\n" +
+	"\x11WordCoOccurrences\x12\x19.analytics.KeywordRequest\x1a$.analytics.WordCoOccurrencesResponse\x12j\n" +
+	"\x15PhraseFrequencyTrends\x12'.analytics.PhraseFrequencyTrendsRequest\x1a(.analytics.PhraseFrequencyTrendsResponse\x12^\n" +
+	"\x11OverallStatistics\x12#.analytics.OverallStatisticsRequest\x1a$.analytics.OverallStatisticsResponseB(Z&golang-restapi/analyticspb;analyticspbb\x06proto3"
+
+var (
+	file_analytics_proto_rawDescOnce sync.Once
+	file_analytics_proto_rawDescData []byte
+)
+
+func file_analytics_proto_rawDescGZIP() []byte {
+	file_analytics_proto_rawDescOnce.Do(func() {
+		file_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analytics_proto_rawDesc), len(file_analytics_proto_rawDesc)))
+	})
+	return file_analytics_proto_rawDescData
+}
+
+var file_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_analytics_proto_goTypes = []any{
+	(*GetFeedsRequest)(nil),               // 0: analytics.GetFeedsRequest
+	(*Feed)(nil),                          // 1: analytics.Feed
+	(*MostCommonWordsRequest)(nil),        // 2: analytics.MostCommonWordsRequest
+	(*WordCount)(nil),                     // 3: analytics.WordCount
+	(*MostCommonWordsResponse)(nil),       // 4: analytics.MostCommonWordsResponse
+	(*DateRangeRequest)(nil),              // 5: analytics.DateRangeRequest
+	(*CountSentimentsResponse)(nil),       // 6: analytics.CountSentimentsResponse
+	(*SentimentGroupedRequest)(nil),       // 7: analytics.SentimentGroupedRequest
+	(*SentimentGroupedResponse)(nil),      // 8: analytics.SentimentGroupedResponse
+	(*TopFeedsRequest)(nil),               // 9: analytics.TopFeedsRequest
+	(*TopFeed)(nil),                       // 10: analytics.TopFeed
+	(*TopFeedsResponse)(nil),              // 11: analytics.TopFeedsResponse
+	(*KeywordRequest)(nil),                // 12: analytics.KeywordRequest
+	(*BiasDetectionRow)(nil),              // 13: analytics.BiasDetectionRow
+	(*BiasDetectionResponse)(nil),         // 14: analytics.BiasDetectionResponse
+	(*CorrelationRow)(nil),                // 15: analytics.CorrelationRow
+	(*CorrelationResponse)(nil),           // 16: analytics.CorrelationResponse
+	(*WordCoOccurrenceRow)(nil),           // 17: analytics.WordCoOccurrenceRow
+	(*WordCoOccurrencesResponse)(nil),     // 18: analytics.WordCoOccurrencesResponse
+	(*PhraseFrequencyTrendsRequest)(nil),  // 19: analytics.PhraseFrequencyTrendsRequest
+	(*PhraseFrequencyRow)(nil),            // 20: analytics.PhraseFrequencyRow
+	(*PhraseFrequencyTrendsResponse)(nil), // 21: analytics.PhraseFrequencyTrendsResponse
+	(*OverallStatisticsRequest)(nil),      // 22: analytics.OverallStatisticsRequest
+	(*OverallStatisticsResponse)(nil),     // 23: analytics.OverallStatisticsResponse
+	(*timestamppb.Timestamp)(nil),         // 24: google.protobuf.Timestamp
+}
+var file_analytics_proto_depIdxs = []int32{
+	24, // 0: analytics.Feed.published:type_name -> google.protobuf.Timestamp
+	3,  // 1: analytics.MostCommonWordsResponse.words:type_name -> analytics.WordCount
+	24, // 2: analytics.TopFeed.published:type_name -> google.protobuf.Timestamp
+	10, // 3: analytics.TopFeedsResponse.feeds:type_name -> analytics.TopFeed
+	13, // 4: analytics.BiasDetectionResponse.rows:type_name -> analytics.BiasDetectionRow
+	15, // 5: analytics.CorrelationResponse.rows:type_name -> analytics.CorrelationRow
+	17, // 6: analytics.WordCoOccurrencesResponse.rows:type_name -> analytics.WordCoOccurrenceRow
+	20, // 7: analytics.PhraseFrequencyTrendsResponse.rows:type_name -> analytics.PhraseFrequencyRow
+	0,  // 8: analytics.AnalyticsService.GetFeeds:input_type -> analytics.GetFeedsRequest
+	2,  // 9: analytics.AnalyticsService.MostCommonWords:input_type -> analytics.MostCommonWordsRequest
+	5,  // 10: analytics.AnalyticsService.CountSentiments:input_type -> analytics.DateRangeRequest
+	7,  // 11: analytics.AnalyticsService.GetSentimentGrouped:input_type -> analytics.SentimentGroupedRequest
+	9,  // 12: analytics.AnalyticsService.TopFeeds:input_type -> analytics.TopFeedsRequest
+	12, // 13: analytics.AnalyticsService.BiasDetection:input_type -> analytics.KeywordRequest
+	12, // 14: analytics.AnalyticsService.CorrelationBetweenSources:input_type -> analytics.KeywordRequest
+	12, // 15: analytics.AnalyticsService.WordCoOccurrences:input_type -> analytics.KeywordRequest
+	19, // 16: analytics.AnalyticsService.PhraseFrequencyTrends:input_type -> analytics.PhraseFrequencyTrendsRequest
+	22, // 17: analytics.AnalyticsService.OverallStatistics:input_type -> analytics.OverallStatisticsRequest
+	1,  // 18: analytics.AnalyticsService.GetFeeds:output_type -> analytics.Feed
+	4,  // 19: analytics.AnalyticsService.MostCommonWords:output_type -> analytics.MostCommonWordsResponse
+	6,  // 20: analytics.AnalyticsService.CountSentiments:output_type -> analytics.CountSentimentsResponse
+	8,  // 21: analytics.AnalyticsService.GetSentimentGrouped:output_type -> analytics.SentimentGroupedResponse
+	11, // 22: analytics.AnalyticsService.TopFeeds:output_type -> analytics.TopFeedsResponse
+	14, // 23: analytics.AnalyticsService.BiasDetection:output_type -> analytics.BiasDetectionResponse
+	16, // 24: analytics.AnalyticsService.CorrelationBetweenSources:output_type -> analytics.CorrelationResponse
+	18, // 25: analytics.AnalyticsService.WordCoOccurrences:output_type -> analytics.WordCoOccurrencesResponse
+	21, // 26: analytics.AnalyticsService.PhraseFrequencyTrends:output_type -> analytics.PhraseFrequencyTrendsResponse
+	23, // 27: analytics.AnalyticsService.OverallStatistics:output_type -> analytics.OverallStatisticsResponse
+	18, // [18:28] is the sub-list for method output_type
+	8,  // [8:18] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_analytics_proto_init() }
+func file_analytics_proto_init() {
+	if File_analytics_proto != nil {
+		return
+	}
+	file_analytics_proto_msgTypes[19].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analytics_proto_rawDesc), len(file_analytics_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analytics_proto_goTypes,
+		DependencyIndexes: file_analytics_proto_depIdxs,
+		MessageInfos:      file_analytics_proto_msgTypes,
+	}.Build()
+	File_analytics_proto = out.File
+	file_analytics_proto_goTypes = nil
+	file_analytics_proto_depIdxs = nil
+}
@@ -0,0 +1,467 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.1
+// source: analytics.proto
+
+package analyticspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_GetFeeds_FullMethodName                  = "/analytics.AnalyticsService/GetFeeds"
+	AnalyticsService_MostCommonWords_FullMethodName           = "/analytics.AnalyticsService/MostCommonWords"
+	AnalyticsService_CountSentiments_FullMethodName           = "/analytics.AnalyticsService/CountSentiments"
+	AnalyticsService_GetSentimentGrouped_FullMethodName       = "/analytics.AnalyticsService/GetSentimentGrouped"
+	AnalyticsService_TopFeeds_FullMethodName                  = "/analytics.AnalyticsService/TopFeeds"
+	AnalyticsService_BiasDetection_FullMethodName             = "/analytics.AnalyticsService/BiasDetection"
+	AnalyticsService_CorrelationBetweenSources_FullMethodName = "/analytics.AnalyticsService/CorrelationBetweenSources"
+	AnalyticsService_WordCoOccurrences_FullMethodName         = "/analytics.AnalyticsService/WordCoOccurrences"
+	AnalyticsService_PhraseFrequencyTrends_FullMethodName     = "/analytics.AnalyticsService/PhraseFrequencyTrends"
+	AnalyticsService_OverallStatistics_FullMethodName         = "/analytics.AnalyticsService/OverallStatistics"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AnalyticsServiceClient interface {
+	GetFeeds(ctx context.Context, in *GetFeedsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Feed], error)
+	MostCommonWords(ctx context.Context, in *MostCommonWordsRequest, opts ...grpc.CallOption) (*MostCommonWordsResponse, error)
+	CountSentiments(ctx context.Context, in *DateRangeRequest, opts ...grpc.CallOption) (*CountSentimentsResponse, error)
+	GetSentimentGrouped(ctx context.Context, in *SentimentGroupedRequest, opts ...grpc.CallOption) (*SentimentGroupedResponse, error)
+	TopFeeds(ctx context.Context, in *TopFeedsRequest, opts ...grpc.CallOption) (*TopFeedsResponse, error)
+	BiasDetection(ctx context.Context, in *KeywordRequest, opts ...grpc.CallOption) (*BiasDetectionResponse, error)
+	CorrelationBetweenSources(ctx context.Context, in *KeywordRequest, opts ...grpc.CallOption) (*CorrelationResponse, error)
+	WordCoOccurrences(ctx context.Context, in *KeywordRequest, opts ...grpc.CallOption) (*WordCoOccurrencesResponse, error)
+	PhraseFrequencyTrends(ctx context.Context, in *PhraseFrequencyTrendsRequest, opts ...grpc.CallOption) (*PhraseFrequencyTrendsResponse, error)
+	OverallStatistics(ctx context.Context, in *OverallStatisticsRequest, opts ...grpc.CallOption) (*OverallStatisticsResponse, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetFeeds(ctx context.Context, in *GetFeedsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Feed], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AnalyticsService_ServiceDesc.Streams[0], AnalyticsService_GetFeeds_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetFeedsRequest, Feed]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalyticsService_GetFeedsClient = grpc.ServerStreamingClient[Feed]
+
+func (c *analyticsServiceClient) MostCommonWords(ctx context.Context, in *MostCommonWordsRequest, opts ...grpc.CallOption) (*MostCommonWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MostCommonWordsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_MostCommonWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) CountSentiments(ctx context.Context, in *DateRangeRequest, opts ...grpc.CallOption) (*CountSentimentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountSentimentsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_CountSentiments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetSentimentGrouped(ctx context.Context, in *SentimentGroupedRequest, opts ...grpc.CallOption) (*SentimentGroupedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SentimentGroupedResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetSentimentGrouped_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) TopFeeds(ctx context.Context, in *TopFeedsRequest, opts ...grpc.CallOption) (*TopFeedsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopFeedsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_TopFeeds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) BiasDetection(ctx context.Context, in *KeywordRequest, opts ...grpc.CallOption) (*BiasDetectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BiasDetectionResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_BiasDetection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) CorrelationBetweenSources(ctx context.Context, in *KeywordRequest, opts ...grpc.CallOption) (*CorrelationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CorrelationResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_CorrelationBetweenSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) WordCoOccurrences(ctx context.Context, in *KeywordRequest, opts ...grpc.CallOption) (*WordCoOccurrencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WordCoOccurrencesResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_WordCoOccurrences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) PhraseFrequencyTrends(ctx context.Context, in *PhraseFrequencyTrendsRequest, opts ...grpc.CallOption) (*PhraseFrequencyTrendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PhraseFrequencyTrendsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_PhraseFrequencyTrends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) OverallStatistics(ctx context.Context, in *OverallStatisticsRequest, opts ...grpc.CallOption) (*OverallStatisticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OverallStatisticsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_OverallStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+type AnalyticsServiceServer interface {
+	GetFeeds(*GetFeedsRequest, grpc.ServerStreamingServer[Feed]) error
+	MostCommonWords(context.Context, *MostCommonWordsRequest) (*MostCommonWordsResponse, error)
+	CountSentiments(context.Context, *DateRangeRequest) (*CountSentimentsResponse, error)
+	GetSentimentGrouped(context.Context, *SentimentGroupedRequest) (*SentimentGroupedResponse, error)
+	TopFeeds(context.Context, *TopFeedsRequest) (*TopFeedsResponse, error)
+	BiasDetection(context.Context, *KeywordRequest) (*BiasDetectionResponse, error)
+	CorrelationBetweenSources(context.Context, *KeywordRequest) (*CorrelationResponse, error)
+	WordCoOccurrences(context.Context, *KeywordRequest) (*WordCoOccurrencesResponse, error)
+	PhraseFrequencyTrends(context.Context, *PhraseFrequencyTrendsRequest) (*PhraseFrequencyTrendsResponse, error)
+	OverallStatistics(context.Context, *OverallStatisticsRequest) (*OverallStatisticsResponse, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) GetFeeds(*GetFeedsRequest, grpc.ServerStreamingServer[Feed]) error {
+	return status.Errorf(codes.Unimplemented, "method GetFeeds not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) MostCommonWords(context.Context, *MostCommonWordsRequest) (*MostCommonWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MostCommonWords not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) CountSentiments(context.Context, *DateRangeRequest) (*CountSentimentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountSentiments not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetSentimentGrouped(context.Context, *SentimentGroupedRequest) (*SentimentGroupedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSentimentGrouped not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) TopFeeds(context.Context, *TopFeedsRequest) (*TopFeedsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopFeeds not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) BiasDetection(context.Context, *KeywordRequest) (*BiasDetectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BiasDetection not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) CorrelationBetweenSources(context.Context, *KeywordRequest) (*CorrelationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CorrelationBetweenSources not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) WordCoOccurrences(context.Context, *KeywordRequest) (*WordCoOccurrencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WordCoOccurrences not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) PhraseFrequencyTrends(context.Context, *PhraseFrequencyTrendsRequest) (*PhraseFrequencyTrendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PhraseFrequencyTrends not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) OverallStatistics(context.Context, *OverallStatisticsRequest) (*OverallStatisticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OverallStatistics not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_GetFeeds_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetFeedsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalyticsServiceServer).GetFeeds(m, &grpc.GenericServerStream[GetFeedsRequest, Feed]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalyticsService_GetFeedsServer = grpc.ServerStreamingServer[Feed]
+
+func _AnalyticsService_MostCommonWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MostCommonWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).MostCommonWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_MostCommonWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).MostCommonWords(ctx, req.(*MostCommonWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_CountSentiments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DateRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).CountSentiments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_CountSentiments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).CountSentiments(ctx, req.(*DateRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetSentimentGrouped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SentimentGroupedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetSentimentGrouped(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetSentimentGrouped_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetSentimentGrouped(ctx, req.(*SentimentGroupedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_TopFeeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopFeedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).TopFeeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_TopFeeds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).TopFeeds(ctx, req.(*TopFeedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_BiasDetection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeywordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).BiasDetection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_BiasDetection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).BiasDetection(ctx, req.(*KeywordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_CorrelationBetweenSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeywordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).CorrelationBetweenSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_CorrelationBetweenSources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).CorrelationBetweenSources(ctx, req.(*KeywordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_WordCoOccurrences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeywordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).WordCoOccurrences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_WordCoOccurrences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).WordCoOccurrences(ctx, req.(*KeywordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_PhraseFrequencyTrends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PhraseFrequencyTrendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).PhraseFrequencyTrends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_PhraseFrequencyTrends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).PhraseFrequencyTrends(ctx, req.(*PhraseFrequencyTrendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_OverallStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverallStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).OverallStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_OverallStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).OverallStatistics(ctx, req.(*OverallStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "analytics.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MostCommonWords",
+			Handler:    _AnalyticsService_MostCommonWords_Handler,
+		},
+		{
+			MethodName: "CountSentiments",
+			Handler:    _AnalyticsService_CountSentiments_Handler,
+		},
+		{
+			MethodName: "GetSentimentGrouped",
+			Handler:    _AnalyticsService_GetSentimentGrouped_Handler,
+		},
+		{
+			MethodName: "TopFeeds",
+			Handler:    _AnalyticsService_TopFeeds_Handler,
+		},
+		{
+			MethodName: "BiasDetection",
+			Handler:    _AnalyticsService_BiasDetection_Handler,
+		},
+		{
+			MethodName: "CorrelationBetweenSources",
+			Handler:    _AnalyticsService_CorrelationBetweenSources_Handler,
+		},
+		{
+			MethodName: "WordCoOccurrences",
+			Handler:    _AnalyticsService_WordCoOccurrences_Handler,
+		},
+		{
+			MethodName: "PhraseFrequencyTrends",
+			Handler:    _AnalyticsService_PhraseFrequencyTrends_Handler,
+		},
+		{
+			MethodName: "OverallStatistics",
+			Handler:    _AnalyticsService_OverallStatistics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetFeeds",
+			Handler:       _AnalyticsService_GetFeeds_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "analytics.proto",
+}
@@ -0,0 +1,297 @@
+// Package backfill re-scores the stored feeds with a sentiment model, e.g.
+// after a new analyzer is deployed behind the SentimentService.
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang-restapi/ingestion"
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+)
+
+// ErrJobRunning is returned when starting a job that already runs.
+var ErrJobRunning = errors.New("backfill job is already running")
+
+// DefaultRunner runs the jobs of the admin endpoints (set in main).
+var DefaultRunner *Runner
+
+// Runner runs backfill jobs in the background, one goroutine per job.
+type Runner struct {
+	Sentiment    utils.SentimentAnalyzer
+	BatchSize    int           // default titles per BatchAnalyze call of a job
+	Concurrency  int           // default parallel BatchAnalyze calls of a job
+	Retries      int           // extra attempts of a failing BatchAnalyze call
+	RetryBackoff time.Duration // first retry delay, doubled on every attempt
+	CallTimeout  time.Duration // deadline of a single BatchAnalyze call
+
+	// OnWrite, if set, is called with the feed_date range (YYYY-MM-DD) of
+	// every page with scores written.
+	OnWrite func(from, to string)
+
+	mu      sync.Mutex
+	running map[int]context.CancelFunc
+}
+
+// NewRunner returns a Runner with sane defaults. batchSize and concurrency
+// must be at least 1.
+func NewRunner(analyzer utils.SentimentAnalyzer, batchSize, concurrency, retries int) (*Runner, error) {
+	if batchSize < 1 {
+		return nil, fmt.Errorf("backfill batch size must be at least 1, got %d", batchSize)
+	}
+	if concurrency < 1 {
+		return nil, fmt.Errorf("backfill concurrency must be at least 1, got %d", concurrency)
+	}
+	return &Runner{
+		Sentiment:    analyzer,
+		BatchSize:    batchSize,
+		Concurrency:  concurrency,
+		Retries:      retries,
+		RetryBackoff: time.Second,
+		CallTimeout:  30 * time.Second,
+		running:      map[int]context.CancelFunc{},
+	}, nil
+}
+
+// Start runs a job in the background from its stored cursor.
+func (r *Runner) Start(job models.BackfillJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.running[job.ID]; ok {
+		return ErrJobRunning
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.running[job.ID] = cancel
+	go func() {
+		defer func() {
+			r.mu.Lock()
+			delete(r.running, job.ID)
+			r.mu.Unlock()
+			cancel()
+		}()
+		r.run(ctx, job)
+	}()
+	return nil
+}
+
+// Pause stops a running job after its current page; false if it was not running.
+func (r *Runner) Pause(id int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cancel, ok := r.running[id]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// Running reports whether a job runs in this process.
+func (r *Runner) Running(id int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.running[id]
+	return ok
+}
+
+// ResumeInterrupted restarts the jobs that were running when the process stopped.
+func (r *Runner) ResumeInterrupted(ctx context.Context) error {
+	jobs, err := repositories.ListInterruptedBackfillJobs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		slog.InfoContext(ctx, "backfill job resumed", slog.Int("job_id", job.ID),
+			slog.String("cursor_month", deref(job.CursorMonth)), slog.Int64("cursor_id", job.CursorID))
+		if err := r.Start(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run walks the feeds partitions of the job range in order. Progress is
+// stored after every page, so a paused or interrupted job continues where
+// it stopped. Feeds of a batch that failed even after the retries are
+// counted as failed and stay unscored; a new job picks them up.
+func (r *Runner) run(ctx context.Context, job models.BackfillJob) {
+	finish := func(status string, err error) {
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+			slog.ErrorContext(ctx, "backfill job failed", slog.Int("job_id", job.ID), slog.Any("error", err))
+		}
+		// the job context may be canceled already
+		if err := repositories.SetBackfillStatus(context.Background(), job.ID, status, msg); err != nil {
+			slog.ErrorContext(ctx, "backfill status not stored", slog.Int("job_id", job.ID),
+				slog.String("status", status), slog.Any("error", err))
+		}
+	}
+
+	if err := repositories.SetBackfillStatus(ctx, job.ID, models.BackfillRunning, ""); err != nil {
+		slog.ErrorContext(ctx, "backfill job not started", slog.Int("job_id", job.ID), slog.Any("error", err))
+		return
+	}
+
+	// a zero batch size would never advance, zero concurrency deadlock
+	if job.BatchSize < 1 {
+		job.BatchSize = max(r.BatchSize, 1)
+	}
+	if job.Concurrency < 1 {
+		job.Concurrency = max(r.Concurrency, 1)
+	}
+
+	parts, err := repositories.ListPartitions(ctx, "feeds")
+	if err != nil {
+		finish(models.BackfillFailed, err)
+		return
+	}
+
+	pageSize := job.BatchSize * job.Concurrency
+	for _, p := range parts {
+		// partitions are listed by name, i.e. in month order
+		if p.To <= job.FromDate || p.From > job.ToDate {
+			continue
+		}
+		if job.CursorMonth != nil && p.From < *job.CursorMonth {
+			continue // done before the job was paused
+		}
+		if job.CursorMonth == nil || p.From != *job.CursorMonth {
+			month := p.From
+			job.CursorMonth, job.CursorID = &month, 0
+		}
+
+		for {
+			if ctx.Err() != nil {
+				finish(models.BackfillPaused, nil)
+				return
+			}
+			feeds, err := repositories.BackfillFeeds(ctx, job, p.From, p.To, job.CursorID, pageSize)
+			if err != nil {
+				if ctx.Err() != nil {
+					finish(models.BackfillPaused, nil)
+				} else {
+					finish(models.BackfillFailed, err)
+				}
+				return
+			}
+			if len(feeds) == 0 {
+				break
+			}
+
+			scored, failed := r.scorePage(ctx, job, feeds)
+			if ctx.Err() != nil {
+				// the page is repeated on resume, its scored feeds are skipped
+				finish(models.BackfillPaused, nil)
+				return
+			}
+			if scored > 0 {
+				r.notifyWrite(feeds)
+			}
+			job.CursorID = int64(feeds[len(feeds)-1].ID)
+			job.Processed += len(feeds)
+			job.Scored += scored
+			job.Failed += failed
+			if err := repositories.SetBackfillProgress(context.Background(), job); err != nil {
+				finish(models.BackfillFailed, err)
+				return
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "backfill job done", slog.Int("job_id", job.ID),
+		slog.Int("processed", job.Processed), slog.Int("scored", job.Scored), slog.Int("failed", job.Failed))
+	finish(models.BackfillDone, nil)
+}
+
+func (r *Runner) notifyWrite(feeds []models.BackfillFeed) {
+	if r.OnWrite == nil {
+		return
+	}
+	from, to := feeds[0].FeedDate, feeds[0].FeedDate
+	for _, f := range feeds[1:] {
+		from, to = min(from, f.FeedDate), max(to, f.FeedDate)
+	}
+	r.OnWrite(from, to)
+}
+
+// scorePage scores a page in batches of BatchSize, Concurrency at a time.
+func (r *Runner) scorePage(ctx context.Context, job models.BackfillJob, feeds []models.BackfillFeed) (scored, failed int) {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, job.Concurrency)
+	)
+	for start := 0; start < len(feeds); start += job.BatchSize {
+		batch := feeds[start:min(start+job.BatchSize, len(feeds))]
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			err := r.scoreBatch(ctx, job.ModelID, batch)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed += len(batch)
+				slog.WarnContext(ctx, "backfill batch failed", slog.Int("job_id", job.ID),
+					slog.Int("first_feed_id", batch[0].ID), slog.Int("feeds", len(batch)), slog.Any("error", err))
+				return
+			}
+			scored += len(batch)
+		}()
+	}
+	wg.Wait()
+	return scored, failed
+}
+
+// scoreBatch analyzes a batch (retrying the call) and upserts the results.
+func (r *Runner) scoreBatch(ctx context.Context, modelID int, feeds []models.BackfillFeed) error {
+	in := make([]utils.SentimentInput, len(feeds))
+	for i, f := range feeds {
+		in[i] = utils.SentimentInput{Text: f.Title, Lang: f.Lang}
+	}
+
+	var results []utils.SentimentResult
+	backoff := r.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		callCtx, cancel := context.WithTimeout(ctx, r.CallTimeout)
+		var err error
+		results, err = r.Sentiment.BatchAnalyze(callCtx, in)
+		cancel()
+		if err == nil {
+			break
+		}
+		if attempt >= r.Retries || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	if len(results) != len(feeds) {
+		return fmt.Errorf("BatchAnalyze returned %d results for %d feeds", len(results), len(feeds))
+	}
+	rows := make([]models.NewFeedSentiment, len(feeds))
+	for i, res := range results {
+		rows[i] = ingestion.SentimentRow(feeds[i].ID, modelID, feeds[i].FeedDate, res)
+	}
+	return repositories.UpsertFeedSentiments(ctx, rows)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
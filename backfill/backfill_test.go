@@ -0,0 +1,304 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang-restapi/db"
+	"golang-restapi/db/dbtest"
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+)
+
+func TestNewRunnerRejectsZeroSizes(t *testing.T) {
+	tests := []struct {
+		batchSize, concurrency int
+		ok                     bool
+	}{
+		{50, 4, true},
+		{1, 1, true},
+		{0, 4, false},
+		{50, 0, false},
+		{-1, 4, false},
+	}
+	for _, tt := range tests {
+		r, err := NewRunner(utils.FakeAnalyzer{}, tt.batchSize, tt.concurrency, 3)
+		if (err == nil) != tt.ok {
+			t.Errorf("NewRunner(batch %d, concurrency %d) err = %v, want ok %v", tt.batchSize, tt.concurrency, err, tt.ok)
+		}
+		if err == nil && (r.BatchSize != tt.batchSize || r.Concurrency != tt.concurrency) {
+			t.Errorf("NewRunner(batch %d, concurrency %d) = %+v", tt.batchSize, tt.concurrency, r)
+		}
+	}
+}
+
+func TestNotifyWrite(t *testing.T) {
+	r, err := NewRunner(utils.FakeAnalyzer{}, 10, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.notifyWrite([]models.BackfillFeed{{FeedDate: "2025-10-02"}}) // no OnWrite
+
+	var from, to string
+	r.OnWrite = func(f, t string) { from, to = f, t }
+	r.notifyWrite([]models.BackfillFeed{
+		{ID: 1, FeedDate: "2025-10-02"},
+		{ID: 2, FeedDate: "2025-09-30"},
+		{ID: 3, FeedDate: "2025-10-05"},
+	})
+	if from != "2025-09-30" || to != "2025-10-05" {
+		t.Errorf("OnWrite(%q, %q), want 2025-09-30..2025-10-05", from, to)
+	}
+}
+
+// batchFunc is an analyzer scoring with a function, e.g. one that fails.
+type batchFunc func(ctx context.Context, in []utils.SentimentInput) ([]utils.SentimentResult, error)
+
+func (f batchFunc) Analyze(ctx context.Context, in utils.SentimentInput) (utils.SentimentResult, error) {
+	out, err := f(ctx, []utils.SentimentInput{in})
+	if err != nil {
+		return utils.SentimentResult{}, err
+	}
+	return out[0], nil
+}
+
+func (f batchFunc) BatchAnalyze(ctx context.Context, in []utils.SentimentInput) ([]utils.SentimentResult, error) {
+	return f(ctx, in)
+}
+
+func fake(ctx context.Context, in []utils.SentimentInput) ([]utils.SentimentResult, error) {
+	return utils.FakeAnalyzer{}.BatchAnalyze(ctx, in)
+}
+
+const modelID = 2
+
+// openJob seeds a database with feeds 1-3 in September and 4-6 in October
+// 2025 and returns a pending job of both months.
+func openJob(t *testing.T, batchSize, concurrency int) models.BackfillJob {
+	t.Helper()
+	conn := dbtest.Open(t)
+	ctx := context.Background()
+	for _, table := range []string{"feeds", "feed_sentiments"} {
+		for _, m := range []time.Time{
+			time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		} {
+			if _, err := repositories.CreateMonthlyPartition(ctx, table, m); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for _, q := range []string{
+		`INSERT INTO sources (id, name, lang) VALUES (1, 'Index', 'hun')`,
+		`INSERT INTO feeds (id, title, link, source_id, feed_date) VALUES
+            (1, 'Egy', 'https://index.hu/1', 1, '2025-09-02'),
+            (2, 'Kettő', 'https://index.hu/2', 1, '2025-09-15'),
+            (3, 'Három', 'https://index.hu/3', 1, '2025-09-30'),
+            (4, 'Négy', 'https://index.hu/4', 1, '2025-10-01'),
+            (5, 'Kudarc', 'https://index.hu/5', 1, '2025-10-02'),
+            (6, 'Hat', 'https://index.hu/6', 1, '2025-10-20')`,
+	} {
+		if _, err := conn.Exec(q); err != nil {
+			t.Fatalf("%s: %v", q, err)
+		}
+	}
+
+	job, err := repositories.CreateBackfillJob(ctx, models.BackfillInput{
+		ModelID:     modelID,
+		StartDate:   "2025-09-01",
+		EndDate:     "2025-10-31",
+		BatchSize:   batchSize,
+		Concurrency: concurrency,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func testRunner(t *testing.T, analyze batchFunc, retries int) *Runner {
+	t.Helper()
+	r, err := NewRunner(analyze, 10, 1, retries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.RetryBackoff = 10 * time.Millisecond
+	return r
+}
+
+// scoredIDs returns the feeds with a score of the job model.
+func scoredIDs(t *testing.T) []int {
+	t.Helper()
+	rows, err := db.DB.Query(`SELECT feed_id FROM feed_sentiments WHERE model_id = $1 ORDER BY feed_id`, modelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func reload(t *testing.T, id int) models.BackfillJob {
+	t.Helper()
+	job, err := repositories.GetBackfillJob(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func TestRunScoresEveryMonth(t *testing.T) {
+	job := openJob(t, 2, 2)
+	var writes int
+	r := testRunner(t, fake, 0)
+	r.OnWrite = func(from, to string) { writes++ }
+
+	r.run(context.Background(), job)
+
+	job = reload(t, job.ID)
+	if job.Status != models.BackfillDone || job.Processed != 6 || job.Scored != 6 || job.Failed != 0 {
+		t.Errorf("job = %s processed=%d scored=%d failed=%d, want done 6/6/0", job.Status, job.Processed, job.Scored, job.Failed)
+	}
+	if deref(job.CursorMonth) != "2025-10-01" || job.CursorID != 6 {
+		t.Errorf("cursor = %s/%d, want 2025-10-01/6", deref(job.CursorMonth), job.CursorID)
+	}
+	if got := scoredIDs(t); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("scored feeds %v", got)
+	}
+	if writes != 2 {
+		t.Errorf("OnWrite called %d times, want once a month", writes)
+	}
+}
+
+func TestRunResumesFromCursor(t *testing.T) {
+	job := openJob(t, 10, 1)
+	month := "2025-10-01"
+	job.CursorMonth, job.CursorID = &month, 4
+	if err := repositories.SetBackfillProgress(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+
+	testRunner(t, fake, 0).run(context.Background(), reload(t, job.ID))
+
+	job = reload(t, job.ID)
+	if job.Status != models.BackfillDone || job.Processed != 2 || job.Scored != 2 {
+		t.Errorf("job = %s processed=%d scored=%d, want done 2/2", job.Status, job.Processed, job.Scored)
+	}
+	if got := scoredIDs(t); !slices.Equal(got, []int{5, 6}) {
+		t.Errorf("scored feeds %v, want only those after the cursor", got)
+	}
+}
+
+func TestRunPausesMidPage(t *testing.T) {
+	job := openJob(t, 1, 2) // pages of two parallel batches of one feed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pausing := func(ctx context.Context, in []utils.SentimentInput) ([]utils.SentimentResult, error) {
+		if in[0].Text == "Kettő" {
+			// paused once the other batch of the first page is written
+			var n int
+			for deadline := time.Now().Add(5 * time.Second); n == 0 && time.Now().Before(deadline); {
+				time.Sleep(5 * time.Millisecond)
+				db.DB.QueryRow(`SELECT count(*) FROM feed_sentiments WHERE model_id = $1`, modelID).Scan(&n)
+			}
+			cancel()
+		}
+		return fake(ctx, in)
+	}
+
+	testRunner(t, pausing, 0).run(ctx, job)
+
+	job = reload(t, job.ID)
+	if job.Status != models.BackfillPaused || job.Processed != 0 || job.CursorMonth != nil {
+		t.Errorf("paused job = %s processed=%d cursor=%s, want paused before its first page was stored",
+			job.Status, job.Processed, deref(job.CursorMonth))
+	}
+	if got := scoredIDs(t); !slices.Equal(got, []int{1}) {
+		t.Errorf("scored feeds %v, want the first batch", got)
+	}
+
+	// the page is repeated, its scored feed is skipped
+	testRunner(t, fake, 0).run(context.Background(), job)
+
+	job = reload(t, job.ID)
+	if job.Status != models.BackfillDone || job.Processed != 5 || job.Scored != 5 {
+		t.Errorf("resumed job = %s processed=%d scored=%d, want done 5/5", job.Status, job.Processed, job.Scored)
+	}
+	if got := scoredIDs(t); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("scored feeds %v", got)
+	}
+}
+
+func TestRunRetriesWithBackoff(t *testing.T) {
+	job := openJob(t, 10, 1)
+	var calls atomic.Int32
+	flaky := func(ctx context.Context, in []utils.SentimentInput) ([]utils.SentimentResult, error) {
+		if calls.Add(1) <= 2 {
+			return nil, errors.New("unavailable")
+		}
+		return fake(ctx, in)
+	}
+
+	start := time.Now()
+	testRunner(t, flaky, 2).run(context.Background(), job)
+
+	// 10ms, then 20ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("retried after %s, want a backoff of at least 30ms", elapsed)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("BatchAnalyze called %d times, want 3", n)
+	}
+	job = reload(t, job.ID)
+	if job.Status != models.BackfillDone || job.Scored != 6 || job.Failed != 0 {
+		t.Errorf("job = %s scored=%d failed=%d, want done 6/0", job.Status, job.Scored, job.Failed)
+	}
+}
+
+func TestRunCountsFailedBatches(t *testing.T) {
+	job := openJob(t, 1, 2)
+	var calls atomic.Int32
+	failing := func(ctx context.Context, in []utils.SentimentInput) ([]utils.SentimentResult, error) {
+		if in[0].Text == "Kudarc" {
+			calls.Add(1)
+			return nil, errors.New("model error")
+		}
+		return fake(ctx, in)
+	}
+
+	testRunner(t, failing, 1).run(context.Background(), job)
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("failing batch called %d times, want 2", n)
+	}
+	job = reload(t, job.ID)
+	if job.Status != models.BackfillDone || job.Processed != 6 || job.Scored != 5 || job.Failed != 1 {
+		t.Errorf("job = %s processed=%d scored=%d failed=%d, want done 6/5/1", job.Status, job.Processed, job.Scored, job.Failed)
+	}
+	if got := scoredIDs(t); !slices.Equal(got, []int{1, 2, 3, 4, 6}) {
+		t.Errorf("scored feeds %v, want all but the failed one", got)
+	}
+}
+
+func TestScoreBatchRejectsMissingResults(t *testing.T) {
+	short := func(ctx context.Context, in []utils.SentimentInput) ([]utils.SentimentResult, error) {
+		return fake(ctx, in[1:])
+	}
+	r := testRunner(t, short, 0)
+	feeds := []models.BackfillFeed{{ID: 1, Title: "Egy"}, {ID: 2, Title: "Kettő"}}
+	if err := r.scoreBatch(context.Background(), modelID, feeds); err == nil {
+		t.Error("scoreBatch stored 1 result for 2 feeds")
+	}
+}
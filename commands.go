@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"golang-restapi/config"
+	"golang-restapi/db"
+	"golang-restapi/dbsync"
+	"golang-restapi/repositories"
+)
+
+const usage = `usage: golang-restapi [command]
+
+Without a command the API server is started.
+
+commands:
+  migrate up [N]     apply all (or the next N) pending migrations
+  migrate down [N]   revert the last (or the last N) migrations
+  migrate status     list the migrations and whether they are applied
+  sync [-full]       copy feeds and sentiments changed since the last sync
+                     from the SYNC_SOURCE_DSN database (-full: everything)
+`
+
+// runCommand runs a subcommand and returns the process exit code.
+func runCommand(cfg config.Config, name string, args []string) int {
+	switch name {
+	case "migrate":
+		return runMigrate(cfg, args)
+	case "sync":
+		return runSync(cfg, args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", name, usage)
+		return 2
+	}
+}
+
+func runMigrate(cfg config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	steps := 0
+	if len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			fmt.Fprintf(os.Stderr, "invalid number of steps %q\n", args[1])
+			return 2
+		}
+		steps = n
+	}
+
+	db.InitDB(cfg)
+	defer db.DB.Close()
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		done, err := db.MigrateUp(ctx, db.DB, steps)
+		for _, m := range done {
+			fmt.Printf("applied  %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(done) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		done, err := db.MigrateDown(ctx, db.DB, steps)
+		for _, m := range done {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	case "status":
+		states, err := db.MigrationStatus(ctx, db.DB)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		for _, s := range states {
+			applied := "pending"
+			if s.Applied != nil {
+				applied = s.Applied.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-20s %s\n", s.Version, s.Name, applied)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	return 0
+}
+
+func runSync(cfg config.Config, args []string) int {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	full := fs.Bool("full", false, "ignore the high-water marks and sync everything")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if cfg.SyncSourceDSN == "" {
+		fmt.Fprintln(os.Stderr, "SYNC_SOURCE_DSN is not set")
+		return 2
+	}
+
+	src, err := sql.Open("postgres", cfg.SyncSourceDSN)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "source database: %v\n", err)
+		return 1
+	}
+	defer src.Close()
+
+	db.InitDB(cfg)
+	defer db.DB.Close()
+
+	syncer := &dbsync.Syncer{Source: src}
+	// the response caches of the API servers drop what the sync wrote
+	syncer.OnWrite = func(from, to string) {
+		if err := repositories.NotifyResponseCache(context.Background(), from, to); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	stats, err := syncer.Run(context.Background(), *full)
+	fmt.Printf("months=%d feeds inserted=%d updated=%d skipped=%d sentiments inserted=%d updated=%d skipped=%d\n",
+		stats.Months,
+		stats.Feeds.Inserted, stats.Feeds.Updated, stats.Feeds.Skipped,
+		stats.Sentiments.Inserted, stats.Sentiments.Updated, stats.Sentiments.Skipped)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
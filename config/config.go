@@ -5,7 +5,9 @@ import (
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds application configuration
@@ -15,19 +17,75 @@ type Config struct {
 	DBUser             string
 	DBPassword         string
 	DBName             string
+	DBSSLMode          string
+	SyncSourceDSN      string
+	JWTSecret          string
 	AuthIssuers        []string
 	AuthEmails         []string
+	AuthAudiences      []string
+	AuthRolesClaim     string
+	AuthClockSkew      time.Duration
+	AuthJWKSRefresh    time.Duration
 	USGSApiHost        string
 	IMDBApiHost        string
 	IMDBApiKey         string
 	CORSAllowedOrigins string
+	TrustedProxies     []string // CIDRs or IPs whose X-Forwarded-For is believed
 	APP_PORT           string
+	GRPCEnabled        bool
+	GRPCPort           string
+	GRPCReflection     bool
+	GRPCTLSCertFile    string // the server listens on loopback only without TLS
+	GRPCTLSKeyFile     string
+	LemmaDictPath      string
+	LogLevel           string
+	LogFormat          string
+	MetricsEnabled     bool
+	TracingExporter    string
+	TracingEndpoint    string
+	IngestEnabled      bool
+	IngestInterval     time.Duration
+	IngestModelID      int
+
+	PartitionsEnabled        bool
+	PartitionMonthsAhead     int
+	PartitionRetentionMonths int
+	PartitionRetentionMode   string // "", "detach" or "archive"
+	PartitionInterval        time.Duration
+
+	RateLimitEnabled    bool
+	RateLimitCapacity   int    // tokens per client per minute, also the burst size
+	RateLimitIPCapacity int    // requests per IP per minute before authentication
+	RateLimitBackend    string // memory or postgres
+
+	ResponseCacheSize      int // 0 disables the cache
+	ResponseCachePastTTL   time.Duration
+	ResponseCacheRecentTTL time.Duration
+
+	BackfillBatchSize   int
+	BackfillConcurrency int
+	BackfillRetries     int
+
+	SentimentBackend          string // grpc, lexicon or fake
+	SentimentAddr             string
+	SentimentTLS              bool
+	SentimentTLSCAFile        string
+	SentimentTLSServerName    string
+	SentimentTimeout          time.Duration
+	SentimentRetries          int
+	SentimentBreakerThreshold int
+	SentimentBreakerCooldown  time.Duration
+
+	SentimentModelVersion string
+	SentimentCacheSize    int
+	SentimentCacheTTL     time.Duration
+	SentimentCacheDB      bool
 }
 
 // LoadConfig loads environment variables from .env
 func LoadConfig() Config {
-	err := godotenv.Load()
-	if err != nil {
+	// a missing .env is fine when the variables come from the environment
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
 		log.Fatal("Error loading .env file")
 	}
 
@@ -36,28 +94,117 @@ func LoadConfig() Config {
 		appPort = "1985" // default
 	}
 
-	var issuers, emails []string
-
-	if envIssuers := os.Getenv("ALLOWED_ISSUERS"); envIssuers != "" {
-		issuers = strings.SplitAfter(envIssuers, ",")
-	}
-
-	if envEmails := os.Getenv("ALLOWED_EMAILS"); envEmails != "" {
-		emails = strings.SplitAfter(envEmails, ",")
-	}
-
 	return Config{
 		DBHost:             os.Getenv("DB_HOST"),
 		DBPort:             os.Getenv("DB_PORT"),
 		DBUser:             os.Getenv("DB_USER"),
 		DBPassword:         os.Getenv("DB_PASSWORD"),
 		DBName:             os.Getenv("DB_NAME"),
+		DBSSLMode:          getEnv("DB_SSLMODE", "require"), // "disable" for a local PostgreSQL
+		SyncSourceDSN:      os.Getenv("SYNC_SOURCE_DSN"),
 		CORSAllowedOrigins: os.Getenv("CORS_ALLOWED_ORIGINS"),
-		AuthIssuers:        issuers,
-		AuthEmails:         emails,
+		TrustedProxies:     getEnvList("TRUSTED_PROXIES"),
+		JWTSecret:          strings.TrimSpace(os.Getenv("JWT_SECRET_KEY")),
+		AuthIssuers:        getEnvList("ALLOWED_ISSUERS"),
+		AuthEmails:         getEnvList("ALLOWED_EMAILS"),
+		AuthAudiences:      getEnvList("AUTH_AUDIENCES"),
+		AuthRolesClaim:     getEnv("AUTH_ROLES_CLAIM", "roles"),
+		AuthClockSkew:      getEnvDuration("AUTH_CLOCK_SKEW", time.Minute),
+		AuthJWKSRefresh:    getEnvDuration("AUTH_JWKS_REFRESH", time.Hour),
 		USGSApiHost:        os.Getenv("USGS_API_HOST"),
 		IMDBApiHost:        os.Getenv("IMDB_BASE_URL"),
 		IMDBApiKey:         os.Getenv("IMDB_API_KEY"),
 		APP_PORT:           appPort,
+		GRPCEnabled:        getEnvBool("GRPC_ENABLED", false), // analytics gRPC server
+		GRPCPort:           getEnv("GRPC_PORT", "50052"),
+		GRPCReflection:     getEnvBool("GRPC_REFLECTION", false),
+		GRPCTLSCertFile:    os.Getenv("GRPC_TLS_CERT_FILE"),
+		GRPCTLSKeyFile:     os.Getenv("GRPC_TLS_KEY_FILE"),
+		LemmaDictPath:      os.Getenv("LEMMA_DICT_PATH"),
+		LogLevel:           getEnv("LOG_LEVEL", "info"),
+		LogFormat:          getEnv("LOG_FORMAT", "json"), // json or text
+		MetricsEnabled:     getEnvBool("METRICS_ENABLED", true),
+		TracingExporter:    getEnv("TRACING_EXPORTER", "none"), // otlp, stdout or none
+		TracingEndpoint:    os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		IngestEnabled:      getEnvBool("INGEST_ENABLED", false),
+		IngestInterval:     getEnvDuration("INGEST_INTERVAL", 15*time.Minute),
+		IngestModelID:      getEnvInt("INGEST_MODEL_ID", 1),
+
+		PartitionsEnabled:        getEnvBool("PARTITIONS_ENABLED", true),
+		PartitionMonthsAhead:     getEnvInt("PARTITION_MONTHS_AHEAD", 3),
+		PartitionRetentionMonths: getEnvInt("PARTITION_RETENTION_MONTHS", 0),
+		PartitionRetentionMode:   strings.ToLower(os.Getenv("PARTITION_RETENTION_MODE")),
+		PartitionInterval:        getEnvDuration("PARTITION_INTERVAL", 24*time.Hour),
+
+		RateLimitEnabled:    getEnvBool("RATE_LIMIT_ENABLED", true),
+		RateLimitCapacity:   getEnvInt("RATE_LIMIT_PER_MINUTE", 60),
+		RateLimitIPCapacity: getEnvInt("RATE_LIMIT_IP_PER_MINUTE", 300),
+		RateLimitBackend:    getEnv("RATE_LIMIT_BACKEND", "memory"),
+
+		ResponseCacheSize:      getEnvInt("RESPONSE_CACHE_SIZE", 1000),
+		ResponseCachePastTTL:   getEnvDuration("RESPONSE_CACHE_PAST_TTL", 24*time.Hour),
+		ResponseCacheRecentTTL: getEnvDuration("RESPONSE_CACHE_RECENT_TTL", time.Minute),
+
+		BackfillBatchSize:   getEnvInt("BACKFILL_BATCH_SIZE", 50),
+		BackfillConcurrency: getEnvInt("BACKFILL_CONCURRENCY", 4),
+		BackfillRetries:     getEnvInt("BACKFILL_RETRIES", 3),
+
+		SentimentBackend:          getEnv("SENTIMENT_BACKEND", "grpc"),
+		SentimentAddr:             getEnv("SENTIMENT_ADDR", "localhost:50051"),
+		SentimentTLS:              getEnvBool("SENTIMENT_TLS", false),
+		SentimentTLSCAFile:        os.Getenv("SENTIMENT_TLS_CA_FILE"),
+		SentimentTLSServerName:    os.Getenv("SENTIMENT_TLS_SERVER_NAME"),
+		SentimentTimeout:          getEnvDuration("SENTIMENT_TIMEOUT", 10*time.Second),
+		SentimentRetries:          getEnvInt("SENTIMENT_RETRIES", 3),
+		SentimentBreakerThreshold: getEnvInt("SENTIMENT_BREAKER_THRESHOLD", 5),
+		SentimentBreakerCooldown:  getEnvDuration("SENTIMENT_BREAKER_COOLDOWN", 30*time.Second),
+
+		SentimentModelVersion: getEnv("SENTIMENT_MODEL_VERSION", "1"), // bump when the analyzer changes
+		SentimentCacheSize:    getEnvInt("SENTIMENT_CACHE_SIZE", 10000),
+		SentimentCacheTTL:     getEnvDuration("SENTIMENT_CACHE_TTL", 30*24*time.Hour),
+		SentimentCacheDB:      getEnvBool("SENTIMENT_CACHE_DB", false),
+	}
+}
+
+// getEnv reads a string env variable, falling back to def if unset.
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// getEnvList reads a comma separated env variable, dropping blank entries.
+func getEnvList(key string) []string {
+	var out []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// getEnvInt reads an int env variable, falling back to def if unset or invalid.
+func getEnvInt(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// getEnvBool reads a bool env variable ("true", "1", ...), falling back to def.
+func getEnvBool(key string, def bool) bool {
+	if v, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// getEnvDuration reads a duration env variable ("15m", "1h"), falling back to def.
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil && v > 0 {
+		return v
 	}
+	return def
 }
@@ -7,24 +7,28 @@ import (
 	"log"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"golang-restapi/config"
+	"golang-restapi/metrics"
 )
 
 var DB *sql.DB
 
-func InitDB(cfg config.Config) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require",
-		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
-
-	var err error
-
-	DB, err = sql.Open("postgres", dsn)
+// DSN is the connection string of the database of cfg.
+func DSN(cfg config.Config) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBSSLMode)
+}
 
+func InitDB(cfg config.Config) {
+	connector, err := pq.NewConnector(DSN(cfg))
 	if err != nil {
 		log.Fatal("Error connecting to the database:", err)
 	}
 
+	// statements are timed per repository function for /metrics
+	DB = sql.OpenDB(metrics.InstrumentConnector(connector))
+
 	// Set connection pool parameters
 	DB.SetMaxOpenConns(10)                  // Max open connections
 	DB.SetMaxIdleConns(5)                   // Max idle connections
@@ -0,0 +1,93 @@
+// Package dbtest gives tests a freshly migrated PostgreSQL database.
+//
+// Tests using it are skipped unless TEST_DATABASE_DSN points at a server
+// on which the user may create databases, e.g.
+//
+//	TEST_DATABASE_DSN="host=localhost user=postgres password=postgres sslmode=disable" go test ./...
+package dbtest
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"golang-restapi/db"
+
+	"github.com/lib/pq"
+)
+
+// DSNEnv names the environment variable with the server to test against.
+const DSNEnv = "TEST_DATABASE_DSN"
+
+// Open creates an empty database, applies every migration and points
+// db.DB at it until the test ends, when the database is dropped. Tests
+// using it must not run in parallel.
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+	conn := OpenEmpty(t)
+	if _, err := db.MigrateUp(context.Background(), conn, 0); err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+
+	prev := db.DB
+	db.DB = conn
+	t.Cleanup(func() { db.DB = prev })
+	return conn
+}
+
+// OpenEmpty creates an empty database, without migrations, dropped when
+// the test ends.
+func OpenEmpty(t testing.TB) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv(DSNEnv)
+	if dsn == "" {
+		t.Skipf("dbtest: %s is not set", DSNEnv)
+	}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		var err error
+		if dsn, err = pq.ParseURL(dsn); err != nil {
+			t.Fatalf("dbtest: %s: %v", DSNEnv, err)
+		}
+	}
+
+	server, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+	defer server.Close()
+
+	b := make([]byte, 6)
+	rand.Read(b)
+	name := "powtest_" + hex.EncodeToString(b)
+	ctx := context.Background()
+	if _, err := server.ExecContext(ctx, "CREATE DATABASE "+pq.QuoteIdentifier(name)); err != nil {
+		t.Fatalf("dbtest: create database: %v", err)
+	}
+
+	// a later dbname overrides the one of the DSN
+	conn, err := sql.Open("postgres", fmt.Sprintf("%s dbname=%s", dsn, name))
+	if err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server, err := sql.Open("postgres", dsn)
+		if err != nil {
+			t.Errorf("dbtest: %v", err)
+			return
+		}
+		defer server.Close()
+		if _, err := server.ExecContext(ctx, "DROP DATABASE IF EXISTS "+pq.QuoteIdentifier(name)+" WITH (FORCE)"); err != nil {
+			t.Errorf("dbtest: drop database %s: %v", name, err)
+		}
+	})
+	if err := conn.PingContext(ctx); err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+	return conn
+}
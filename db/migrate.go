@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang-restapi/models"
+	"golang-restapi/queries"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration is a versioned schema change: migrations/NNNN_name.up.sql and
+// its optional NNNN_name.down.sql.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// LoadMigrations returns the embedded migrations ordered by version.
+func LoadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("LoadMigrations: read error: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		m := migrationFile.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("LoadMigrations: unexpected file %s", e.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := migrationFiles.ReadFile("migrations/" + e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("LoadMigrations: read error: %w", err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("LoadMigrations: version %d used by %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("LoadMigrations: %04d_%s has no up file", mig.Version, mig.Name)
+		}
+		out = append(out, *mig)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// appliedMigrations returns the applied versions and their apply time.
+func appliedMigrations(ctx context.Context, conn *sql.DB) (map[int]time.Time, error) {
+	if _, err := conn.ExecContext(ctx, queries.CreateSchemaMigrations); err != nil {
+		return nil, fmt.Errorf("appliedMigrations: create error: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, queries.ListAppliedMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("appliedMigrations: query error: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var name string
+		var at time.Time
+		if err := rows.Scan(&version, &name, &at); err != nil {
+			return nil, fmt.Errorf("appliedMigrations: scan error: %w", err)
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("appliedMigrations: rows iteration error: %w", err)
+	}
+	return applied, nil
+}
+
+// MigrateUp applies up to steps pending migrations (all if steps <= 0), each
+// in its own transaction. It returns the applied migrations.
+func MigrateUp(ctx context.Context, conn *sql.DB, steps int) ([]Migration, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for _, mig := range migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if steps > 0 && len(done) == steps {
+			break
+		}
+		if err := runMigration(ctx, conn, mig.Up, queries.InsertMigration, mig.Version, mig.Name); err != nil {
+			return done, fmt.Errorf("MigrateUp: %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// MigrateDown reverts the last steps applied migrations (1 if steps <= 0).
+func MigrateDown(ctx context.Context, conn *sql.DB, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		if mig.Down == "" {
+			return done, fmt.Errorf("MigrateDown: %04d_%s has no down file", mig.Version, mig.Name)
+		}
+		if err := runMigration(ctx, conn, mig.Down, queries.DeleteMigration, mig.Version); err != nil {
+			return done, fmt.Errorf("MigrateDown: %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// MigrationStatus lists every known migration with its apply time.
+func MigrationStatus(ctx context.Context, conn *sql.DB) ([]models.MigrationState, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.MigrationState, 0, len(migrations))
+	for _, mig := range migrations {
+		state := models.MigrationState{Version: mig.Version, Name: mig.Name}
+		if at, ok := applied[mig.Version]; ok {
+			state.Applied = &at
+		}
+		out = append(out, state)
+	}
+	return out, nil
+}
+
+// runMigration executes a migration script and records it in one transaction.
+func runMigration(ctx context.Context, conn *sql.DB, script, record string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("exec error: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("record error: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"golang-restapi/db"
+	"golang-restapi/db/dbtest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := db.LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %d has version %d, want consecutive versions", i, m.Version)
+		}
+		if m.Down == "" {
+			t.Errorf("%04d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
+
+func TestMigrateUpDown(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.OpenEmpty(t)
+
+	migrations, err := db.LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up, err := db.MigrateUp(ctx, conn, 0)
+	if err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	if len(up) != len(migrations) {
+		t.Fatalf("applied %d migrations, want %d", len(up), len(migrations))
+	}
+	if again, err := db.MigrateUp(ctx, conn, 0); err != nil || len(again) != 0 {
+		t.Fatalf("second migrate up applied %d, err %v; want nothing", len(again), err)
+	}
+
+	down, err := db.MigrateDown(ctx, conn, len(migrations))
+	if err != nil {
+		t.Fatalf("migrate down: %v", err)
+	}
+	if len(down) != len(migrations) {
+		t.Fatalf("reverted %d migrations, want %d", len(down), len(migrations))
+	}
+	states, err := db.MigrationStatus(ctx, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range states {
+		if s.Applied != nil {
+			t.Errorf("%04d_%s still applied after migrate down", s.Version, s.Name)
+		}
+	}
+
+	// the down scripts leave nothing behind that breaks a new up
+	if _, err := db.MigrateUp(ctx, conn, 0); err != nil {
+		t.Fatalf("migrate up after down: %v", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	conn := dbtest.Open(t)
+	if db.DB != conn {
+		t.Fatal("dbtest.Open did not point db.DB at the test database")
+	}
+	var n int
+	if err := conn.QueryRow("SELECT count(*) FROM feeds").Scan(&n); err != nil {
+		t.Fatalf("feeds table: %v", err)
+	}
+}
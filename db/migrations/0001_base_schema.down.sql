@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS public.feed_sentiments;
+DROP TABLE IF EXISTS public.feeds;
+DROP TABLE IF EXISTS public.feed_categories;
+DROP TABLE IF EXISTS public.sources;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS public.hun_unaccent;
@@ -0,0 +1,79 @@
+/* ======================================================================
+   BASE SCHEMA — sources, categories and the monthly partitioned
+   feeds / feed_sentiments tables
+   Partitions (feeds_YYYY_MM, feed_sentiments_YYYY_MM) are created by the
+   partition manager, not here.
+   ====================================================================== */
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- Hungarian full text search without accents (used by search_vector)
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM pg_ts_config c
+    JOIN pg_namespace n ON n.oid = c.cfgnamespace
+    WHERE n.nspname = 'public' AND c.cfgname = 'hun_unaccent'
+  ) THEN
+    CREATE TEXT SEARCH CONFIGURATION public.hun_unaccent (COPY = pg_catalog.hungarian);
+    ALTER TEXT SEARCH CONFIGURATION public.hun_unaccent
+      ALTER MAPPING FOR hword, hword_part, word WITH unaccent, hungarian_stem;
+  END IF;
+END$$;
+
+CREATE TABLE IF NOT EXISTS public.sources (
+  id       serial PRIMARY KEY,
+  name     text        NOT NULL,
+  url      text,
+  created  timestamptz NOT NULL DEFAULT now(),
+  updated  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS public.feed_categories (
+  id       int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name     text        NOT NULL,
+  alias    text,
+  created  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS public.feeds (
+  id             bigint GENERATED BY DEFAULT AS IDENTITY,
+  title          text        NOT NULL,
+  link           text        NOT NULL,
+  source_id      int         NOT NULL REFERENCES public.sources (id),
+  category_id    int         REFERENCES public.feed_categories (id),
+  words          text[],
+  words_masked   text[],
+  published      timestamptz,
+  feed_date      date        NOT NULL,
+  search_vector  tsvector,
+  created        timestamptz NOT NULL DEFAULT now(),
+  updated        timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (id, feed_date)
+) PARTITION BY RANGE (feed_date);
+
+-- feed_id has no foreign key: the primary key of feeds includes feed_date
+CREATE TABLE IF NOT EXISTS public.feed_sentiments (
+  id                  bigint GENERATED BY DEFAULT AS IDENTITY,
+  feed_id             bigint      NOT NULL,
+  model_id            int         NOT NULL,
+  sentiments          jsonb,
+  sentiment_key       text,
+  sentiment_value     double precision,
+  sentiment_compound  double precision,
+  feed_date           date        NOT NULL,
+  created             timestamptz NOT NULL DEFAULT now(),
+  updated             timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (id, feed_date)
+) PARTITION BY RANGE (feed_date);
+
+/* ----------------------------------------------------------------------
+   Indexes (created on every partition)
+   ---------------------------------------------------------------------- */
+CREATE INDEX IF NOT EXISTS ix_feeds_feed_date           ON public.feeds (feed_date);
+CREATE INDEX IF NOT EXISTS ix_feeds_source_id_feed_date ON public.feeds (source_id, feed_date);
+CREATE INDEX IF NOT EXISTS ix_feeds_updated             ON public.feeds (updated);
+CREATE INDEX IF NOT EXISTS ix_feeds_search_vector       ON public.feeds USING gin (search_vector);
+
+CREATE INDEX IF NOT EXISTS ix_feed_sentiments_feed_id        ON public.feed_sentiments (feed_id);
+CREATE INDEX IF NOT EXISTS ix_feed_sentiments_model_feed_date ON public.feed_sentiments (model_id, feed_date);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.entity_aliases;
+DROP TABLE IF EXISTS public.entities;
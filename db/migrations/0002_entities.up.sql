@@ -0,0 +1,58 @@
+/* ======================================================================
+   ENTITY DICTIONARY — canonical entities and their aliases / stems
+   ====================================================================== */
+
+CREATE TABLE IF NOT EXISTS public.entities (
+  id       serial PRIMARY KEY,
+  name     text        NOT NULL UNIQUE,
+  kind     text        NOT NULL DEFAULT 'person',  -- person | party | organization | place
+  created  timestamptz NOT NULL DEFAULT now(),
+  updated  timestamptz NOT NULL DEFAULT now()
+);
+
+-- is_stem = true matches every surface form starting with the alias
+-- ("Orbán" -> "Orbánt", "Orbánnak"), otherwise the alias must match exactly.
+CREATE TABLE IF NOT EXISTS public.entity_aliases (
+  id         serial PRIMARY KEY,
+  entity_id  int         NOT NULL REFERENCES public.entities (id) ON DELETE CASCADE,
+  alias      text        NOT NULL,
+  is_stem    boolean     NOT NULL DEFAULT false,
+  created    timestamptz NOT NULL DEFAULT now(),
+  UNIQUE (entity_id, alias, is_stem)
+);
+
+CREATE INDEX IF NOT EXISTS ix_entity_aliases_entity_id ON public.entity_aliases (entity_id);
+
+/* ----------------------------------------------------------------------
+   Seed — the curated dictionary
+   ---------------------------------------------------------------------- */
+INSERT INTO public.entities (name, kind) VALUES
+  ('Orbán Viktor',         'person'),
+  ('Magyar Péter',         'person'),
+  ('Karácsony Gergely',    'person'),
+  ('Donald Trump',         'person'),
+  ('Vlagyimir Putyin',     'person'),
+  ('Volodimir Zelenszkij', 'person'),
+  ('Fidesz',               'party'),
+  ('Tisza Párt',           'party'),
+  ('Európai Unió',         'organization')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO public.entity_aliases (entity_id, alias, is_stem)
+SELECT e.id, a.alias, a.is_stem
+FROM (VALUES
+  ('Orbán Viktor',         'Orbán',             true),
+  ('Magyar Péter',         'Magyar Péter',      true),
+  ('Karácsony Gergely',    'Karácsony Gergely', true),
+  ('Donald Trump',         'Trump',             true),
+  ('Vlagyimir Putyin',     'Putyin',            true),
+  ('Volodimir Zelenszkij', 'Zelenszk',          true),
+  ('Fidesz',               'Fidesz',            true),
+  ('Tisza Párt',           'Tisza Párt',        true),
+  ('Tisza Párt',           'Tisza-párt',        true),
+  ('Európai Unió',         'EU',                false),
+  ('Európai Unió',         'EU-',               true),
+  ('Európai Unió',         'Európai Uni',       true)
+) AS a (entity, alias, is_stem)
+JOIN public.entities e ON e.name = a.entity
+ON CONFLICT (entity_id, alias, is_stem) DO NOTHING;
@@ -0,0 +1,64 @@
+/* ======================================================================
+   STOPWORDS — single source of truth for word and phrase stop-lists
+   kind: 'word'   -> single tokens skipped by the word analytics
+         'phrase' -> bigrams skipped by the phrase trends
+   ====================================================================== */
+
+CREATE TABLE IF NOT EXISTS public.stopwords (
+  lang     text        NOT NULL DEFAULT 'hun',
+  word     text        NOT NULL,
+  kind     text        NOT NULL DEFAULT 'word' CHECK (kind IN ('word', 'phrase')),
+  created  timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (lang, word, kind)
+);
+
+/* ----------------------------------------------------------------------
+   Seed — merged from the former hard-coded Go lists
+   ---------------------------------------------------------------------- */
+INSERT INTO public.stopwords (lang, word, kind)
+SELECT 'hun', w, 'word'
+FROM (VALUES
+  ('1'), ('2021'), ('2022'), ('2023'), ('2024'), ('2025'), ('2026'), ('a'), ('abban'),
+  ('ahhoz'), ('ahogy'), ('ahol'), ('aki'), ('akik'), ('akkor'), ('alatt'), ('amely'),
+  ('amelyek'), ('amelyekben'), ('amelyeket'), ('amelyet'), ('amelynek'), ('ami'),
+  ('amikor'), ('amit'), ('amolyan'), ('amíg'), ('annak'), ('arra'), ('arról'), ('az'),
+  ('azok'), ('azon'), ('azonban'), ('azt'), ('aztán'), ('azután'), ('azzal'),
+  ('azért'), ('azóta'), ('be'), ('belül'), ('benne'), ('bizonyos'), ('bár'), ('cikk'),
+  ('cikkek'), ('cikkeket'), ('csak'), ('de'), ('e'), ('ebben'), ('eddig'), ('egy'),
+  ('egyes'), ('egyetlen'), ('egyik'), ('egyre'), ('egyéb'), ('egész'), ('ehhez'),
+  ('ekkor'), ('el'), ('ellen'), ('elsõ'), ('első'), ('elég'), ('elõ'), ('elõször'),
+  ('elõtt'), ('elő'), ('először'), ('előtt'), ('emilyen'), ('ennek'), ('erre'),
+  ('es'), ('ez'), ('ezek'), ('ezen'), ('ezt'), ('ezzel'), ('ezért'), ('ezúttal'),
+  ('f1'), ('f2'), ('fel'), ('felé'), ('ha'), ('hanem'), ('hiszen'), ('hogy'), ('hogyan'),
+  ('hát'), ('i'), ('ide'), ('igen'), ('ill'), ('ill.'), ('illetve'), ('ilyen'),
+  ('ilyenkor'), ('is'), ('ismét'), ('ison'), ('itt'), ('jobban'), ('jó'), ('jól'),
+  ('kell'), ('kellett'), ('keressünk'), ('keresztül'), ('ki'), ('kívül'),
+  ('között'), ('közül'), ('le'), ('legalább'), ('legyen'), ('lehet'), ('lehetett'),
+  ('lenne'), ('lenni'), ('lesz'), ('lett'), ('m1'), ('m2'), ('maga'), ('magyar'),
+  ('magát'), ('majd'), ('meg'), ('megint'), ('mellett'), ('mellé'), ('mely'),
+  ('melyek'), ('mert'), ('mi'), ('mikor'), ('milyen'), ('minden'), ('mindenki'),
+  ('mindent'), ('mindig'), ('mint'), ('mintha'), ('mit'), ('mivel'), ('miért'), ('most'),
+  ('már'), ('más'), ('másik'), ('másként'), ('másnap'), ('mások'), ('még'),
+  ('mégis'), ('míg'), ('nagy'), ('nagyobb'), ('nagyon'), ('ne'), ('nekem'), ('neki'),
+  ('nem'), ('nincs'), ('néha'), ('néhány'), ('nélkül'), ('nézzük'), ('oda'),
+  ('olyan'), ('ott'), ('pedig'), ('persze'), ('rá'), ('s'), ('saját'), ('sem'),
+  ('semmi'), ('sok'), ('sokat'), ('sokkal'), ('szemben'), ('szerint'), ('szinte'),
+  ('számára'), ('szét'), ('talán'), ('te'), ('tehát'), ('teljes'), ('ti'),
+  ('tovább'), ('továbbá'), ('több'), ('túl'), ('ugyanis'), ('utolsó'), ('után'),
+  ('utána'), ('vagy'), ('vagyis'), ('vagyok'), ('valaki'), ('valami'), ('valamint'),
+  ('való'), ('van'), ('vannak'), ('vele'), ('videó'), ('vissza'), ('viszont'),
+  ('volna'), ('volt'), ('voltak'), ('voltam'), ('voltunk'), ('által'), ('általában'),
+  ('át'), ('én'), ('éppen'), ('és'), ('év'), ('így'), ('õ'), ('õk'), ('õket'),
+  ('ön'), ('össze'), ('úgy'), ('új'), ('újabb'), ('újra'), ('ő'), ('ők'),
+  ('őket'), ('őt')
+) AS v (w)
+ON CONFLICT DO NOTHING;
+
+-- Date-like bigrams ("2025. május") are filtered by the phrase query itself.
+INSERT INTO public.stopwords (lang, word, kind)
+SELECT 'hun', w, 'phrase'
+FROM (VALUES
+  ('friss hírek'), ('hírek forgalom'), ('reggeli pakk'), ('forgalom időjárás'),
+  ('legfontosabb hírei'), ('időjárás reggeli'), ('nap legfontosabb'), ('heti nyerőszámok')
+) AS v (w)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DELETE FROM public.stopwords WHERE lang IN ('eng', 'dan');
+
+DROP INDEX IF EXISTS public.ix_feeds_lang_feed_date;
+ALTER TABLE public.feeds   DROP COLUMN IF EXISTS lang;
+ALTER TABLE public.sources DROP COLUMN IF EXISTS lang;
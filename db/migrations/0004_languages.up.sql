@@ -0,0 +1,53 @@
+/* ======================================================================
+   LANGUAGES — lang dimension on sources and feeds
+   Codes follow the sentiment analyzers: 'hun', 'eng', 'dan'.
+   ====================================================================== */
+
+ALTER TABLE public.sources ADD COLUMN IF NOT EXISTS lang text NOT NULL DEFAULT 'hun';
+ALTER TABLE public.feeds   ADD COLUMN IF NOT EXISTS lang text NOT NULL DEFAULT 'hun';
+
+-- feeds inherit the language of their source
+UPDATE public.feeds f
+SET lang = s.lang
+FROM public.sources s
+WHERE s.id = f.source_id AND f.lang <> s.lang;
+
+CREATE INDEX IF NOT EXISTS ix_feeds_lang_feed_date ON public.feeds (lang, feed_date);
+
+-- search_vector must be built with the text search config of the language
+UPDATE public.feeds
+SET search_vector = to_tsvector(
+      CASE lang WHEN 'eng' THEN 'pg_catalog.english'
+                WHEN 'dan' THEN 'pg_catalog.danish'
+      END::regconfig,
+      title)
+WHERE lang IN ('eng', 'dan');
+
+/* ----------------------------------------------------------------------
+   Stopwords for the non-Hungarian analyzers
+   ---------------------------------------------------------------------- */
+INSERT INTO public.stopwords (lang, word, kind)
+SELECT 'eng', w, 'word'
+FROM (VALUES
+  ('a'), ('about'), ('after'), ('all'), ('also'), ('an'), ('and'), ('are'), ('as'), ('at'),
+  ('be'), ('been'), ('but'), ('by'), ('can'), ('could'), ('did'), ('do'), ('for'), ('from'),
+  ('had'), ('has'), ('have'), ('he'), ('her'), ('his'), ('how'), ('i'), ('if'), ('in'),
+  ('into'), ('is'), ('it'), ('its'), ('more'), ('new'), ('no'), ('not'), ('of'), ('on'),
+  ('or'), ('our'), ('out'), ('over'), ('says'), ('she'), ('so'), ('than'), ('that'), ('the'),
+  ('their'), ('them'), ('they'), ('this'), ('to'), ('up'), ('us'), ('was'), ('we'), ('were'),
+  ('what'), ('when'), ('who'), ('will'), ('with'), ('would'), ('you')
+) AS v (w)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public.stopwords (lang, word, kind)
+SELECT 'dan', w, 'word'
+FROM (VALUES
+  ('af'), ('alle'), ('andet'), ('at'), ('blev'), ('blive'), ('bliver'), ('da'), ('de'), ('dem'),
+  ('den'), ('denne'), ('der'), ('det'), ('dette'), ('dig'), ('din'), ('du'), ('efter'), ('eller'),
+  ('en'), ('er'), ('et'), ('for'), ('fra'), ('få'), ('går'), ('ham'), ('han'), ('hans'),
+  ('har'), ('havde'), ('have'), ('hende'), ('hun'), ('hvad'), ('hvis'), ('hvor'), ('i'), ('ikke'),
+  ('ind'), ('jeg'), ('kan'), ('man'), ('med'), ('meget'), ('men'), ('mig'), ('nu'), ('når'),
+  ('og'), ('også'), ('om'), ('op'), ('os'), ('over'), ('på'), ('sig'), ('sin'), ('skal'),
+  ('som'), ('til'), ('ud'), ('under'), ('var'), ('vi'), ('vil'), ('ved'), ('være')
+) AS v (w)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS public.uq_feed_sent;
+DROP INDEX IF EXISTS public.uq_feeds_source_link_date;
+
+ALTER TABLE public.sources DROP COLUMN IF EXISTS last_polled;
+ALTER TABLE public.sources DROP COLUMN IF EXISTS active;
+ALTER TABLE public.sources DROP COLUMN IF EXISTS rss_url;
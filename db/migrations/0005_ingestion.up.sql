@@ -0,0 +1,14 @@
+/* ======================================================================
+   INGESTION — RSS feeds polled per source
+   ====================================================================== */
+
+ALTER TABLE public.sources ADD COLUMN IF NOT EXISTS rss_url     text;
+ALTER TABLE public.sources ADD COLUMN IF NOT EXISTS active      boolean NOT NULL DEFAULT true;
+ALTER TABLE public.sources ADD COLUMN IF NOT EXISTS last_polled timestamptz;
+
+-- dedupe key of the poller (also the ON CONFLICT target of the sync)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_feeds_source_link_date
+  ON public.feeds (source_id, link, feed_date);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_feed_sent
+  ON public.feed_sentiments (feed_id, model_id, feed_date);
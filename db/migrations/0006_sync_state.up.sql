@@ -0,0 +1,9 @@
+/* ======================================================================
+   SYNC STATE — high-water marks of the incremental DB-to-DB sync
+   ====================================================================== */
+
+CREATE TABLE IF NOT EXISTS public.sync_state (
+  name        text        PRIMARY KEY,  -- 'feeds' | 'feed_sentiments'
+  high_water  timestamptz NOT NULL,     -- max(updated) already synced
+  updated     timestamptz NOT NULL DEFAULT now()
+);
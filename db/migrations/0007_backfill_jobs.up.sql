@@ -0,0 +1,25 @@
+/* ======================================================================
+   BACKFILL JOBS — re-scoring the history with a (new) sentiment model
+   The cursor (month, last feed id) makes a job resumable after a restart.
+   ====================================================================== */
+
+CREATE TABLE IF NOT EXISTS public.backfill_jobs (
+  id            serial PRIMARY KEY,
+  model_id      int         NOT NULL,
+  from_date     date        NOT NULL,
+  to_date       date        NOT NULL,
+  batch_size    int         NOT NULL,
+  concurrency   int         NOT NULL,
+  status        text        NOT NULL DEFAULT 'pending'
+                CHECK (status IN ('pending', 'running', 'paused', 'done', 'failed')),
+  cursor_month  date,
+  cursor_id     bigint      NOT NULL DEFAULT 0,
+  processed     int         NOT NULL DEFAULT 0,
+  scored        int         NOT NULL DEFAULT 0,
+  failed        int         NOT NULL DEFAULT 0,
+  error         text,
+  created       timestamptz NOT NULL DEFAULT now(),
+  updated       timestamptz NOT NULL DEFAULT now(),
+  started       timestamptz,
+  finished      timestamptz
+);
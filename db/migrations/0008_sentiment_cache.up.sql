@@ -0,0 +1,17 @@
+/* ======================================================================
+   SENTIMENT CACHE — results of the analyzer by content hash
+   key = sha256(model_version, lang, text); rows of other model versions
+   and rows older than the TTL are purged at startup.
+   ====================================================================== */
+
+CREATE TABLE IF NOT EXISTS public.sentiment_cache (
+  key              text             PRIMARY KEY,
+  model_version    text             NOT NULL,
+  sentiment_key    text             NOT NULL,
+  sentiment_value  double precision NOT NULL,
+  sentiments       jsonb            NOT NULL,
+  created          timestamptz      NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_sentiment_cache_model_created
+  ON public.sentiment_cache (model_version, created);
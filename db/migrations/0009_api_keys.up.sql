@@ -0,0 +1,18 @@
+/* ======================================================================
+   API KEYS — for scripts calling /pow/* without a JWT
+   Only the sha256 of a key is stored; the key itself is shown once.
+   rate_limit is requests per minute, 0 means unlimited.
+   ====================================================================== */
+
+CREATE TABLE IF NOT EXISTS public.api_keys (
+  id            serial PRIMARY KEY,
+  name          text        NOT NULL,
+  prefix        text        NOT NULL,
+  key_hash      text        NOT NULL UNIQUE,
+  scopes        text[]      NOT NULL DEFAULT '{}',
+  rate_limit    int         NOT NULL DEFAULT 0 CHECK (rate_limit >= 0),
+  request_count bigint      NOT NULL DEFAULT 0,
+  last_used     timestamptz,
+  created       timestamptz NOT NULL DEFAULT now(),
+  revoked       timestamptz
+);
@@ -0,0 +1,14 @@
+/* ======================================================================
+   USERS — roles of JWT users, by the email claim of their tokens
+   Roles from the token's roles claim are added to these.
+   ====================================================================== */
+
+CREATE TABLE IF NOT EXISTS public.users (
+  id       serial PRIMARY KEY,
+  email    text        NOT NULL,
+  roles    text[]      NOT NULL DEFAULT '{}',
+  created  timestamptz NOT NULL DEFAULT now(),
+  updated  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_users_email ON public.users (lower(email));
@@ -0,0 +1,11 @@
+/* ======================================================================
+   RATE LIMITS — token buckets shared by every API replica
+   (RATE_LIMIT_BACKEND=postgres). Buckets idle for a day are purged.
+   ====================================================================== */
+
+CREATE UNLOGGED TABLE IF NOT EXISTS public.rate_limit_buckets (
+  key           text             PRIMARY KEY,
+  tokens        double precision NOT NULL,
+  last_allowed  boolean          NOT NULL,
+  updated       timestamptz      NOT NULL DEFAULT now()
+);
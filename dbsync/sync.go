@@ -0,0 +1,178 @@
+// Package dbsync copies new and changed feeds and sentiments from another
+// database of the project (same schema) into this one, month by month.
+package dbsync
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+)
+
+// High-water mark names in sync_state.
+const (
+	feedsState      = "feeds"
+	sentimentsState = "feed_sentiments"
+)
+
+// overlap is read again before the high-water marks on every run. A source
+// row is stamped updated = now() at the start of its transaction, so a row
+// committed after a run read the source may be older than the mark it set.
+// The upserts skip rows not newer than the target, so the overlap is only
+// read, not written, twice.
+const overlap = 5 * time.Minute
+
+// Syncer syncs the source database into db.DB.
+type Syncer struct {
+	Source *sql.DB
+
+	// OnWrite, if set, is called with the feed_date range (YYYY-MM-DD) of
+	// the feeds or sentiments a month inserted or updated.
+	OnWrite func(from, to string)
+}
+
+// Run syncs everything updated after the stored high-water marks, less the
+// overlap (everything if full), up to the current time of the source. The
+// marks are only moved once every month went through, so a failed run is
+// simply repeated.
+func (s *Syncer) Run(ctx context.Context, full bool) (models.SyncStats, error) {
+	var stats models.SyncStats
+
+	var feedsHW, sentimentsHW time.Time
+	if !full {
+		var err error
+		if feedsHW, err = repositories.SyncHighWater(ctx, feedsState); err != nil {
+			return stats, err
+		}
+		if sentimentsHW, err = repositories.SyncHighWater(ctx, sentimentsState); err != nil {
+			return stats, err
+		}
+		feedsHW, sentimentsHW = rewind(feedsHW), rewind(sentimentsHW)
+	}
+
+	upTo, err := repositories.SourceNow(ctx, s.Source)
+	if err != nil {
+		return stats, err
+	}
+	months, err := repositories.SourceChangedMonths(ctx, s.Source, feedsHW, sentimentsHW, upTo)
+	if err != nil {
+		return stats, err
+	}
+
+	langs, err := repositories.TargetSourceLangs(ctx)
+	if err != nil {
+		return stats, err
+	}
+	categories, err := repositories.TargetCategoryIDs(ctx)
+	if err != nil {
+		return stats, err
+	}
+
+	// every month's feeds go first: a sentiment may belong to a feed of a
+	// later month, which must exist before the sentiment can be matched
+	feedCounts := make([]models.SyncCounts, len(months))
+	for i, month := range months {
+		if feedCounts[i], err = s.syncFeeds(ctx, month, feedsHW, upTo, langs, categories); err != nil {
+			return stats, fmt.Errorf("sync %s: %w", month.Format("2006-01"), err)
+		}
+	}
+	for i, month := range months {
+		sentiments, err := s.syncSentiments(ctx, month, sentimentsHW, upTo)
+		if err != nil {
+			return stats, fmt.Errorf("sync %s: %w", month.Format("2006-01"), err)
+		}
+		slog.InfoContext(ctx, "month synced", slog.String("month", month.Format("2006-01")),
+			slog.Any("feeds", feedCounts[i]), slog.Any("sentiments", sentiments))
+
+		stats.Months++
+		addCounts(&stats.Feeds, feedCounts[i])
+		addCounts(&stats.Sentiments, sentiments)
+	}
+
+	if err := repositories.SetSyncHighWater(ctx, feedsState, upTo); err != nil {
+		return stats, err
+	}
+	if err := repositories.SetSyncHighWater(ctx, sentimentsState, upTo); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// syncFeeds copies the feeds of month changed after feedsHW.
+func (s *Syncer) syncFeeds(ctx context.Context, month, feedsHW, upTo time.Time,
+	langs map[int]string, categories map[int64]bool) (models.SyncCounts, error) {
+	from, to := month, month.AddDate(0, 1, 0)
+
+	for _, table := range []string{"feeds", "feed_sentiments"} {
+		if _, err := repositories.CreateMonthlyPartition(ctx, table, month); err != nil {
+			return models.SyncCounts{}, err
+		}
+	}
+
+	feeds, err := repositories.SourceFeeds(ctx, s.Source, from, to, feedsHW, upTo)
+	if err != nil {
+		return models.SyncCounts{}, err
+	}
+	counts, err := repositories.SyncFeeds(ctx, feeds, langs, categories)
+	if err == nil && counts.Inserted+counts.Updated > 0 {
+		s.notifyWrite(from, to.AddDate(0, 0, -1))
+	}
+	return counts, err
+}
+
+// syncSentiments copies the sentiments of month changed after sentimentsHW.
+// Their feeds must have been synced already.
+func (s *Syncer) syncSentiments(ctx context.Context, month, sentimentsHW, upTo time.Time) (models.SyncCounts, error) {
+	from, to := month, month.AddDate(0, 1, 0)
+
+	sentiments, err := repositories.SourceFeedSentiments(ctx, s.Source, from, to, sentimentsHW, upTo)
+	if err != nil || len(sentiments) == 0 {
+		return models.SyncCounts{}, err
+	}
+
+	// a sentiment is normally dated like its feed, but not necessarily
+	// in the same month: look the feeds up over the whole span
+	feedFrom, feedTo := sentiments[0].FeedDate, sentiments[0].FeedDate
+	for _, r := range sentiments {
+		if r.FeedDate.Before(feedFrom) {
+			feedFrom = r.FeedDate
+		}
+		if r.FeedDate.After(feedTo) {
+			feedTo = r.FeedDate
+		}
+	}
+	feedIDs, err := repositories.TargetFeedIDs(ctx, feedFrom, feedTo.AddDate(0, 0, 1))
+	if err != nil {
+		return models.SyncCounts{}, err
+	}
+	counts, err := repositories.SyncFeedSentiments(ctx, sentiments, feedIDs)
+	if err == nil && counts.Inserted+counts.Updated > 0 {
+		s.notifyWrite(feedFrom, feedTo)
+	}
+	return counts, err
+}
+
+func (s *Syncer) notifyWrite(from, to time.Time) {
+	if s.OnWrite != nil {
+		s.OnWrite(from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+}
+
+// rewind moves a high-water mark back by the overlap; a zero mark (never
+// synced) stays zero.
+func rewind(hw time.Time) time.Time {
+	if hw.IsZero() {
+		return hw
+	}
+	return hw.Add(-overlap)
+}
+
+func addCounts(total *models.SyncCounts, c models.SyncCounts) {
+	total.Inserted += c.Inserted
+	total.Updated += c.Updated
+	total.Skipped += c.Skipped
+}
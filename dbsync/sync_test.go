@@ -0,0 +1,135 @@
+package dbsync
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"golang-restapi/db"
+	"golang-restapi/db/dbtest"
+	"golang-restapi/repositories"
+)
+
+func exec(t *testing.T, conn *sql.DB, query string, args ...any) {
+	t.Helper()
+	if _, err := conn.Exec(query, args...); err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+}
+
+// openSource returns a migrated source database holding two feeds of
+// source 1, ids 100 and 101, and a sentiment of each. The sentiment of
+// feed 100 (September 30) is dated October 1.
+func openSource(t *testing.T) *sql.DB {
+	t.Helper()
+	src := dbtest.OpenEmpty(t)
+	if _, err := db.MigrateUp(context.Background(), src, 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"feeds", "feed_sentiments"} {
+		for _, m := range [][2]string{{"2025_09", "2025-09-01"}, {"2025_10", "2025-10-01"}} {
+			exec(t, src, `CREATE TABLE `+table+`_`+m[0]+` PARTITION OF `+table+`
+                FOR VALUES FROM ('`+m[1]+`') TO ('`+m[1]+`'::date + interval '1 month')`)
+		}
+	}
+	exec(t, src, `INSERT INTO sources (id, name, lang) VALUES (1, 'Index', 'hun')`)
+	exec(t, src, `INSERT INTO feeds (id, title, link, source_id, words, feed_date) VALUES
+        (100, 'Szeptember', 'https://index.hu/100', 1, '{szeptember}', '2025-09-30'),
+        (101, 'Október', 'https://index.hu/101', 1, '{október}', '2025-10-02')`)
+	exec(t, src, `INSERT INTO feed_sentiments (feed_id, model_id, sentiment_key, sentiment_value, sentiment_compound, feed_date) VALUES
+        (100, 1, 'negative', 0.8, -0.6, '2025-10-01'),
+        (101, 1, 'positive', 0.7, 0.4, '2025-10-02')`)
+	return src
+}
+
+func TestSyncerRun(t *testing.T) {
+	target := dbtest.Open(t)
+	src := openSource(t)
+	exec(t, target, `INSERT INTO sources (id, name, lang) VALUES (1, 'Index', 'hun')`)
+	ctx := context.Background()
+
+	var writes [][2]string
+	s := &Syncer{Source: src, OnWrite: func(from, to string) { writes = append(writes, [2]string{from, to}) }}
+
+	stats, err := s.Run(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Months != 2 || stats.Feeds.Inserted != 2 || stats.Sentiments.Inserted != 2 {
+		t.Errorf("first run = %+v, want 2 months, 2 feeds and 2 sentiments inserted", stats)
+	}
+	if len(writes) == 0 {
+		t.Error("OnWrite not called")
+	}
+
+	// the sentiments point at the target ids of their feeds
+	rows, err := target.Query(`
+        SELECT f.link, s.sentiment_compound, s.feed_id IN (100, 101)
+        FROM feed_sentiments s JOIN feeds f ON f.id = s.feed_id
+        ORDER BY f.link`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	want := map[string]float64{"https://index.hu/100": -0.6, "https://index.hu/101": 0.4}
+	n := 0
+	for rows.Next() {
+		var link string
+		var compound float64
+		var sourceID bool
+		if err := rows.Scan(&link, &compound, &sourceID); err != nil {
+			t.Fatal(err)
+		}
+		if want[link] != compound || sourceID {
+			t.Errorf("sentiment of %s = %v (source feed id kept: %v)", link, compound, sourceID)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("%d sentiments joined to their feeds, want 2", n)
+	}
+
+	// nothing changed: the overlap is read again but not written
+	stats, err = s.Run(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Feeds.Inserted+stats.Feeds.Updated+stats.Sentiments.Inserted+stats.Sentiments.Updated != 0 {
+		t.Errorf("second run wrote %+v", stats)
+	}
+
+	// a row committed after the last run, stamped before its mark
+	hw, err := repositories.SyncHighWater(ctx, feedsState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec(t, src, `INSERT INTO feeds (id, title, link, source_id, feed_date, updated)
+        VALUES (102, 'Késő', 'https://index.hu/102', 1, '2025-10-03', $1)`, hw.Add(-time.Minute))
+	exec(t, src, `UPDATE feeds SET title = 'Október, frissítve', updated = now() WHERE id = 101`)
+
+	stats, err = s.Run(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Feeds.Inserted != 1 || stats.Feeds.Updated != 1 {
+		t.Errorf("third run = %+v, want the late feed inserted and the edited one updated", stats.Feeds)
+	}
+	var title string
+	if err := target.QueryRow(`SELECT title FROM feeds WHERE link = 'https://index.hu/101'`).Scan(&title); err != nil {
+		t.Fatal(err)
+	}
+	if title != "Október, frissítve" {
+		t.Errorf("updated title = %q", title)
+	}
+}
+
+func TestRewind(t *testing.T) {
+	if !rewind(time.Time{}).IsZero() {
+		t.Error("a zero mark was moved")
+	}
+	hw := time.Date(2025, 10, 16, 12, 0, 0, 0, time.UTC)
+	if got := rewind(hw); !got.Equal(hw.Add(-overlap)) {
+		t.Errorf("rewind(%s) = %s", hw, got)
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var apiKeyScopes = []string{models.ScopeReadFeeds, models.ScopeReadAnalytics, models.ScopeReadMetrics, models.ScopeAdmin}
+
+// ListAPIKeys GET /admin/api_keys — keys with their usage, never the secret
+func ListAPIKeys(c *gin.Context) {
+	keys, err := repositories.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch api keys"})
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// CreateAPIKey POST /admin/api_keys
+// {"name": "nightly export", "scopes": ["read:feeds"], "rate_limit": 60}
+// The key is only returned in this response.
+func CreateAPIKey(c *gin.Context) {
+	var in models.APIKeyInput
+	if err := c.ShouldBindJSON(&in); err != nil || strings.TrimSpace(in.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+	in.Name = strings.TrimSpace(in.Name)
+	if len(in.Scopes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one scope is required"})
+		return
+	}
+	for _, s := range in.Scopes {
+		if !slices.Contains(apiKeyScopes, s) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown scope " + s,
+				"scopes": apiKeyScopes})
+			return
+		}
+	}
+	if in.RateLimit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rate_limit must not be negative"})
+		return
+	}
+
+	key, prefix, err := utils.GenerateAPIKey()
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate api key"})
+		return
+	}
+	stored, err := repositories.CreateAPIKey(c.Request.Context(), in, prefix, utils.HashAPIKey(key))
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create api key"})
+		return
+	}
+	c.JSON(http.StatusCreated, models.IssuedAPIKey{APIKey: stored, Key: key})
+}
+
+// RevokeAPIKey DELETE /admin/api_keys/:id
+func RevokeAPIKey(c *gin.Context) {
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	err := repositories.RevokeAPIKey(c.Request.Context(), id)
+	if errors.Is(err, repositories.ErrAPIKeyNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "api key not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke api key"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
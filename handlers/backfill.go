@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"golang-restapi/backfill"
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListBackfillJobs GET /admin/backfill
+func ListBackfillJobs(c *gin.Context) {
+	jobs, err := repositories.ListBackfillJobs(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch backfill jobs"})
+		return
+	}
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetBackfillJob GET /admin/backfill/:id — the progress of a job
+func GetBackfillJob(c *gin.Context) {
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	job, err := repositories.GetBackfillJob(c.Request.Context(), id)
+	if errors.Is(err, repositories.ErrBackfillJobNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "backfill job not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch backfill job"})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// CreateBackfillJob POST /admin/backfill
+// {"model_id": 2, "start_date": "2024-01-01", "end_date": "2025-08-31", "batch_size": 50, "concurrency": 4}
+// Creates the job and starts it.
+func CreateBackfillJob(c *gin.Context) {
+	runner := backfill.DefaultRunner
+	if runner == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "backfill is not available"})
+		return
+	}
+
+	var in models.BackfillInput
+	if err := c.ShouldBindJSON(&in); err != nil || in.ModelID < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "model_id is required"})
+		return
+	}
+	start, err := time.Parse(feedDateLayout, in.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format"})
+		return
+	}
+	end, err := time.Parse(feedDateLayout, in.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format"})
+		return
+	}
+	if end.Before(start) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date is before start_date"})
+		return
+	}
+	if in.BatchSize < 1 {
+		in.BatchSize = runner.BatchSize
+	}
+	if in.Concurrency < 1 {
+		in.Concurrency = runner.Concurrency
+	}
+
+	job, err := repositories.CreateBackfillJob(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create backfill job"})
+		return
+	}
+	if err := runner.Start(job); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start backfill job"})
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
+
+// PauseBackfillJob POST /admin/backfill/:id/pause
+func PauseBackfillJob(c *gin.Context) {
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if backfill.DefaultRunner == nil || !backfill.DefaultRunner.Pause(id) {
+		c.JSON(http.StatusConflict, gin.H{"error": "backfill job is not running"})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"id": id, "status": models.BackfillPaused})
+}
+
+// ResumeBackfillJob POST /admin/backfill/:id/resume — continues a paused or failed job
+func ResumeBackfillJob(c *gin.Context) {
+	runner := backfill.DefaultRunner
+	if runner == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "backfill is not available"})
+		return
+	}
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	job, err := repositories.GetBackfillJob(c.Request.Context(), id)
+	if errors.Is(err, repositories.ErrBackfillJobNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "backfill job not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch backfill job"})
+		return
+	}
+	if job.Status == models.BackfillDone {
+		c.JSON(http.StatusConflict, gin.H{"error": "backfill job is done"})
+		return
+	}
+
+	if err := runner.Start(job); errors.Is(err, backfill.ErrJobRunning) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start backfill job"})
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Entities GET /pow/entities?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD[&sources=1,2&lang=hun&limit=30]
+func Entities(c *gin.Context) {
+	start := c.Query("start_date")
+	end := c.Query("end_date")
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date and end_date are required (YYYY-MM-DD)"})
+		return
+	}
+	if _, err := time.Parse(feedDateLayout, start); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format"})
+		return
+	}
+	if _, err := time.Parse(feedDateLayout, end); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format"})
+		return
+	}
+
+	limitVal, err := strconv.Atoi(c.DefaultQuery("limit", "30"))
+	if err != nil || limitVal < 1 {
+		limitVal = 30
+	}
+
+	srcIDs := utils.ParseIntList(c.Query("sources"))
+
+	lang, ok := langParam(c)
+	if !ok {
+		return
+	}
+
+	rows, err := repositories.Entities(c.Request.Context(), start, end, lang, srcIDs, limitVal)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch entities"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
+
+// EntityTimeline GET /pow/entity_timeline?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&entity=name[&date_group=day&sources=1,2&lang=hun]
+func EntityTimeline(c *gin.Context) {
+	start := c.Query("start_date")
+	end := c.Query("end_date")
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date and end_date are required (YYYY-MM-DD)"})
+		return
+	}
+	if _, err := time.Parse(feedDateLayout, start); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format"})
+		return
+	}
+	if _, err := time.Parse(feedDateLayout, end); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format"})
+		return
+	}
+
+	entity := strings.TrimSpace(c.Query("entity"))
+	if entity == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "entity parameter is required"})
+		return
+	}
+
+	dateGroup := strings.ToLower(c.DefaultQuery("date_group", "day"))
+	switch dateGroup {
+	case "day", "week", "month":
+		// ok
+	default:
+		dateGroup = "day"
+	}
+
+	srcIDs := utils.ParseIntList(c.Query("sources"))
+
+	lang, ok := langParam(c)
+	if !ok {
+		return
+	}
+
+	rows, err := repositories.EntityTimeline(c.Request.Context(), start, end, lang, entity, dateGroup, srcIDs)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch entity timeline"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang-restapi/middlewares"
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListEntities GET /admin/entities
+func ListEntities(c *gin.Context) {
+	rows, err := repositories.ListEntities(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch entities"})
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
+
+// GetEntity GET /admin/entities/:id
+func GetEntity(c *gin.Context) {
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	e, err := repositories.GetEntity(c.Request.Context(), id)
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch entity"})
+		return
+	}
+	c.JSON(http.StatusOK, e)
+}
+
+// CreateEntity POST /admin/entities {"name": "...", "kind": "person", "aliases": [{"alias": "...", "is_stem": true}]}
+func CreateEntity(c *gin.Context) {
+	var in models.EntityInput
+	if err := c.ShouldBindJSON(&in); err != nil || strings.TrimSpace(in.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	e, err := repositories.CreateEntity(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create entity"})
+		return
+	}
+	// the entity counts of every cached range change
+	middlewares.DefaultResponseCache.Purge()
+	c.JSON(http.StatusCreated, e)
+}
+
+// UpdateEntity PUT /admin/entities/:id — replaces the name, kind and aliases
+func UpdateEntity(c *gin.Context) {
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var in models.EntityInput
+	if err := c.ShouldBindJSON(&in); err != nil || strings.TrimSpace(in.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	e, err := repositories.UpdateEntity(c.Request.Context(), id, in)
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update entity"})
+		return
+	}
+	middlewares.DefaultResponseCache.Purge()
+	c.JSON(http.StatusOK, e)
+}
+
+// DeleteEntity DELETE /admin/entities/:id
+func DeleteEntity(c *gin.Context) {
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	err := repositories.DeleteEntity(c.Request.Context(), id)
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete entity"})
+		return
+	}
+	middlewares.DefaultResponseCache.Purge()
+	c.Status(http.StatusNoContent)
+}
+
+// AddEntityAlias POST /admin/entities/:id/aliases {"alias": "...", "is_stem": true}
+func AddEntityAlias(c *gin.Context) {
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var in models.EntityAliasInput
+	if err := c.ShouldBindJSON(&in); err != nil || strings.TrimSpace(in.Alias) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "alias is required"})
+		return
+	}
+
+	a, err := repositories.AddEntityAlias(c.Request.Context(), id, in)
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add alias"})
+		return
+	}
+	middlewares.DefaultResponseCache.Purge()
+	c.JSON(http.StatusCreated, a)
+}
+
+// DeleteEntityAlias DELETE /admin/entities/:id/aliases/:alias_id
+func DeleteEntityAlias(c *gin.Context) {
+	id, ok := entityIDParam(c, "id")
+	if !ok {
+		return
+	}
+	aliasID, ok := entityIDParam(c, "alias_id")
+	if !ok {
+		return
+	}
+
+	err := repositories.DeleteEntityAlias(c.Request.Context(), id, aliasID)
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "alias not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete alias"})
+		return
+	}
+	middlewares.DefaultResponseCache.Purge()
+	c.Status(http.StatusNoContent)
+}
+
+// entityIDParam parses a positive int path parameter, writing a 400 if it is invalid.
+func entityIDParam(c *gin.Context, name string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return id, true
+}
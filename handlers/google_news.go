@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang-restapi/models"
+	"golang-restapi/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// googleNewsLocales maps our language codes to the hl and gl parameters of Google News.
+var googleNewsLocales = map[string][2]string{
+	"hun": {"hu", "HU"},
+	"eng": {"en", "US"},
+	"dan": {"da", "DK"},
+}
+
+var googleNewsPeriod = regexp.MustCompile(`^[0-9]+[hdy]$`)
+
+// lexiconAnalyzer is the fallback of the ad-hoc analysis.
+var lexiconAnalyzer = utils.NewLexiconAnalyzer()
+
+// GoogleNews GET /pow/google_news?q=...[&lang=hun&period=7d]
+// Fetches Google News and scores the titles on the fly; nothing is stored.
+// Falls back to the in-process lexicon analyzer if the configured one fails.
+func GoogleNews(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+	lang, ok := langParam(c)
+	if !ok {
+		return
+	}
+	period := c.Query("period")
+	if period != "" && !googleNewsPeriod.MatchString(period) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "period must look like 12h, 7d or 1y"})
+		return
+	}
+
+	locale := googleNewsLocales[lang]
+	items, err := utils.GetGoogleNews(c.Request.Context(), q, period, locale[0], locale[1])
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch Google News"})
+		return
+	}
+
+	in := make([]utils.SentimentInput, len(items))
+	for i, item := range items {
+		in[i] = utils.SentimentInput{Text: item.Title, Lang: lang}
+	}
+
+	var analyzer utils.SentimentAnalyzer = lexiconAnalyzer
+	if utils.DefaultAnalyzer != nil {
+		analyzer = utils.FallbackAnalyzer{Primary: utils.DefaultAnalyzer, Fallback: lexiconAnalyzer}
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	results, err := analyzer.BatchAnalyze(ctx, in)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to analyze titles"})
+		return
+	}
+
+	out := make([]models.GNewsResponse, len(items))
+	for i, item := range items {
+		out[i] = models.GNewsResponse{
+			Title:          item.Title,
+			Link:           item.Link,
+			Source:         item.Source,
+			Published:      item.Published,
+			SentimentKey:   results[i].Key,
+			SentimentValue: float32(results[i].Value),
+		}
+	}
+	c.JSON(http.StatusOK, out)
+}
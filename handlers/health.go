@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang-restapi/models"
+	"golang-restapi/partitions"
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+	"golang-restapi/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds /readyz, so a hung dependency reports instead of
+// holding the probe until it times out.
+const readinessTimeout = 5 * time.Second
+
+// Healthz GET /healthz
+// Liveness: the process serves HTTP. Dependencies are checked by /readyz.
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz GET /readyz
+// Readiness: pings the database, checks the health of the SentimentService
+// (when sentiments come from it) and, with partitionsEnabled, that feeds and
+// feed_sentiments have a partition for the current month. Answers 503 with
+// the failing checks; their errors are logged, not returned.
+func Readyz(partitionsEnabled bool) gin.HandlerFunc {
+	m := &partitions.Manager{Tables: partitions.Tables}
+	return func(c *gin.Context) {
+		checks := map[string]func(context.Context) error{
+			"database": repositories.Ping,
+		}
+		if a, ok := utils.DefaultAnalyzer.(interface{ Check(context.Context) error }); ok {
+			checks["sentiment"] = a.Check
+		}
+		if partitionsEnabled {
+			checks["partitions"] = func(ctx context.Context) error {
+				return m.CheckCurrent(ctx, time.Now())
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+		defer cancel()
+
+		res := models.Readiness{Status: "ready", Checks: make(map[string]string, len(checks))}
+		var errs []error
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for name, check := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := check(ctx)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					res.Status = "unavailable"
+					res.Checks[name] = "failed"
+					errs = append(errs, fmt.Errorf("readyz %s: %w", name, err))
+					return
+				}
+				res.Checks[name] = "ok"
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			c.Error(err)
+		}
+
+		if res.Status != "ready" {
+			c.JSON(http.StatusServiceUnavailable, res)
+			return
+		}
+		c.JSON(http.StatusOK, res)
+	}
+}
+
+// Version GET /version
+// The git commit and build time of the binary, to verify deploys.
+func Version(c *gin.Context) {
+	c.JSON(http.StatusOK, version.Info())
+}
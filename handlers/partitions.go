@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"golang-restapi/partitions"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PartitionCoverage GET /admin/partitions
+// Reports the partitions of feeds and feed_sentiments and the months missing
+// up to monthsAhead after the current one.
+func PartitionCoverage(monthsAhead int) gin.HandlerFunc {
+	m := &partitions.Manager{Tables: partitions.Tables, MonthsAhead: monthsAhead}
+	return func(c *gin.Context) {
+		reports, err := m.Check(c.Request.Context(), time.Now())
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch partitions"})
+			return
+		}
+		c.JSON(http.StatusOK, reports)
+	}
+}
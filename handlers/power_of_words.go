@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,7 +15,17 @@ import (
 
 const feedDateLayout = "2006-01-02"
 
-// GET /feeds?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&sources=1,2&free_text=word&page=1&items_per_page=30
+// langParam reads the lang query parameter (default hun), writing a 400 if it is not supported.
+func langParam(c *gin.Context) (string, bool) {
+	lang, err := utils.ParseLang(c.Query("lang"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return lang, true
+}
+
+// GET /feeds?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&sources=1,2&free_text=word&lang=hun&page=1&items_per_page=30
 func GetFeeds(c *gin.Context) {
 
 	start := c.Query("start_date")
@@ -36,6 +47,13 @@ func GetFeeds(c *gin.Context) {
 	// query params
 	srcIDs := utils.ParseIntList(c.Query("sources"))
 	freeText := c.Query("free_text")
+	lang := ""
+	if c.Query("lang") != "" {
+		var ok bool
+		if lang, ok = langParam(c); !ok {
+			return
+		}
+	}
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	perPage, _ := strconv.Atoi(c.DefaultQuery("items_per_page", "30"))
 
@@ -44,9 +62,11 @@ func GetFeeds(c *gin.Context) {
 		start, end,
 		srcIDs,
 		freeText,
+		lang,
 		page, perPage,
 	)
 	if err != nil {
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -54,7 +74,7 @@ func GetFeeds(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
-// GET /most_common_words?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&nm_common=20
+// GET /most_common_words?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&nm_common=20[&lang=hun&normalize=none|stem|lemma]
 func MostCommonWordsHandler(c *gin.Context) {
 
 	start := c.Query("start_date")
@@ -75,8 +95,20 @@ func MostCommonWordsHandler(c *gin.Context) {
 		n = 20
 	}
 
-	result, repoErr := repositories.MostCommonWords(c.Request.Context(), start, end, n)
+	lang, ok := langParam(c)
+	if !ok {
+		return
+	}
+
+	norm, err := utils.NormalizerFor(c.DefaultQuery("normalize", utils.NormalizeNone), lang)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, repoErr := repositories.MostCommonWords(c.Request.Context(), start, end, lang, n, norm)
 	if repoErr != nil {
+		c.Error(repoErr)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": repoErr.Error()})
 		return
 	}
@@ -112,6 +144,7 @@ func GetSentimentGrouped(c *gin.Context) {
 	)
 	if err != nil {
 		// In dev, you can expose err.Error(); in prod, return generic.
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch grouped sentiments"})
 		return
 	}
@@ -140,6 +173,7 @@ func CountSentiments(c *gin.Context) {
 
 	counts, err := repositories.CountSentiments(c.Request.Context(), start, end)
 	if err != nil {
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count sentiments"})
 		return
 	}
@@ -180,6 +214,7 @@ func TopFeeds(c *gin.Context) {
 
 	rows, repoErr := repositories.TopFeeds(c.Request.Context(), start, end, posNeg, limitVal)
 	if repoErr != nil {
+		c.Error(repoErr)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch top feeds"})
 		return
 	}
@@ -187,7 +222,7 @@ func TopFeeds(c *gin.Context) {
 	c.JSON(http.StatusOK, rows)
 }
 
-// BiasDetection GET /bias_detection?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&words=a,b,c[&sources=1,2]
+// BiasDetection GET /bias_detection?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&word=foo|entity=<id>[&lang=hun]
 func BiasDetection(c *gin.Context) {
 
 	start := c.Query("start_date")
@@ -206,17 +241,30 @@ func BiasDetection(c *gin.Context) {
 	}
 
 	word := strings.TrimSpace(c.Query("word"))
-	if word == "" || len(strings.Fields(word)) != 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "word is required and must be a single token"})
+	entityID, _ := strconv.Atoi(c.Query("entity"))
+	if entityID <= 0 && (word == "" || len(strings.Fields(word)) != 1) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "word (a single token) or entity is required"})
+		return
+	}
+
+	lang, ok := langParam(c)
+	if !ok {
 		return
 	}
 
 	rows, err := repositories.BiasDetection(
 		c.Request.Context(),
 		start, end,
+		lang,
 		word,
+		entityID,
 	)
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		return
+	}
 	if err != nil {
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute bias detection"})
 		return
 	}
@@ -224,7 +272,7 @@ func BiasDetection(c *gin.Context) {
 	c.JSON(http.StatusOK, rows)
 }
 
-// CorrelationBetweenSourcesAvgCompound GET /correlation_between_sources_avg_compound
+// CorrelationBetweenSourcesAvgCompound GET /correlation_between_sources_avg_compound?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&word=foo|entity=<id>[&sources=1,2&lang=hun]
 func CorrelationBetweenSourcesAvgCompound(c *gin.Context) {
 
 	start := c.Query("start_date")
@@ -243,8 +291,9 @@ func CorrelationBetweenSourcesAvgCompound(c *gin.Context) {
 	}
 
 	word := strings.TrimSpace(c.Query("word"))
-	if word == "" || len(strings.Fields(word)) != 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "word is required and must be a single token"})
+	entityID, _ := strconv.Atoi(c.Query("entity"))
+	if entityID <= 0 && (word == "" || len(strings.Fields(word)) != 1) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "word (a single token) or entity is required"})
 		return
 	}
 
@@ -260,20 +309,32 @@ func CorrelationBetweenSourcesAvgCompound(c *gin.Context) {
 		srcIDs = utils.ParseIntList(c.Query("sources"))
 	}
 
+	lang, ok := langParam(c)
+	if !ok {
+		return
+	}
+
 	rows, err := repositories.CorrelationBetweenSourcesAvgCompound(
 		c.Request.Context(),
 		start, end,
+		lang,
 		word,
+		entityID,
 		srcIDs,
 	)
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		return
+	}
 	if err != nil {
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute correlation"})
 		return
 	}
 	c.JSON(http.StatusOK, rows)
 }
 
-// WordCoOccurrences GET /word_co_occurences?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&word=foo[&sources=1,2]
+// WordCoOccurrences GET /word_co_occurences?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&word=foo|entity=<id>[&sources=1,2&lang=hun&normalize=none|stem|lemma]
 func WordCoOccurrences(c *gin.Context) {
 	start := c.Query("start_date")
 	end := c.Query("end_date")
@@ -291,8 +352,9 @@ func WordCoOccurrences(c *gin.Context) {
 	}
 
 	word := c.Query("word")
-	if word == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "word parameter is required"})
+	entityID, _ := strconv.Atoi(c.Query("entity"))
+	if word == "" && entityID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "word or entity parameter is required"})
 		return
 	}
 
@@ -300,13 +362,32 @@ func WordCoOccurrences(c *gin.Context) {
 	srcIDs := []int{}
 	srcIDs = utils.ParseIntList(c.Query("sources"))
 
+	lang, ok := langParam(c)
+	if !ok {
+		return
+	}
+
+	norm, err := utils.NormalizerFor(c.DefaultQuery("normalize", utils.NormalizeNone), lang)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	rows, err := repositories.WordCoOccurrences(
 		c.Request.Context(),
 		start, end,
+		lang,
 		word,
+		entityID,
 		srcIDs,
+		norm,
 	)
+	if errors.Is(err, repositories.ErrEntityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		return
+	}
 	if err != nil {
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute co-occurrences",
 			"details": err.Error()})
 		return
@@ -315,7 +396,7 @@ func WordCoOccurrences(c *gin.Context) {
 	c.JSON(http.StatusOK, rows)
 }
 
-// GET /phrase_frequency_trends?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD
+// GET /phrase_frequency_trends?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD[&date_group=month&sources=1,2&lang=hun&names_excluded=true]
 func PhraseFrequencyTrends(c *gin.Context) {
 	start := c.Query("start_date")
 	end := c.Query("end_date")
@@ -342,14 +423,21 @@ func PhraseFrequencyTrends(c *gin.Context) {
 	// sources
 	srcIDs := utils.ParseIntList(c.Query("sources"))
 
+	lang, ok := langParam(c)
+	if !ok {
+		return
+	}
+
 	rows, err := repositories.PhraseFrequencyTrends(
 		c.Request.Context(),
 		start, end,
 		dateGroup,
+		lang,
 		srcIDs,
 		namesExcluded, // now a bool
 	)
 	if err != nil {
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "failed to compute phrase frequency trends",
 			"details": err.Error(),
@@ -363,6 +451,7 @@ func PhraseFrequencyTrends(c *gin.Context) {
 func OverallStatistics(c *gin.Context) {
 	stats, err := repositories.OverallStatistics(c.Request.Context())
 	if err != nil {
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch overall statistics"})
 		return
 	}
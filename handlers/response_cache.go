@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"golang-restapi/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseCacheStats GET /admin/response_cache — hit/miss counters of the analytics response cache
+func ResponseCacheStats(c *gin.Context) {
+	cache := middlewares.DefaultResponseCache
+	if cache == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "response cache is disabled"})
+		return
+	}
+	c.JSON(http.StatusOK, cache.Stats())
+}
+
+// PurgeResponseCache DELETE /admin/response_cache — drops every cached response
+func PurgeResponseCache(c *gin.Context) {
+	cache := middlewares.DefaultResponseCache
+	if cache == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "response cache is disabled"})
+		return
+	}
+	cache.Purge()
+	c.Status(http.StatusNoContent)
+}
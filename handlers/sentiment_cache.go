@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+
+	"golang-restapi/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SentimentCacheStats GET /admin/sentiment_cache — hit/miss counters of the sentiment cache
+func SentimentCacheStats(c *gin.Context) {
+	cache, ok := utils.DefaultAnalyzer.(*utils.CachedAnalyzer)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "sentiment cache is disabled"})
+		return
+	}
+	c.JSON(http.StatusOK, cache.Stats())
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"golang-restapi/middlewares"
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListStopwords GET /admin/stopwords[?lang=hun&kind=word|phrase]
+func ListStopwords(c *gin.Context) {
+	lang := strings.ToLower(c.Query("lang"))
+	kind := strings.ToLower(c.Query("kind"))
+	if kind != "" && !validStopwordKind(kind) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be word or phrase"})
+		return
+	}
+
+	rows, err := repositories.ListStopwords(c.Request.Context(), lang, kind)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch stopwords"})
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
+
+// AddStopwords POST /admin/stopwords {"lang": "hun", "kind": "word", "words": ["...", "..."]}
+func AddStopwords(c *gin.Context) {
+	var in models.StopwordsInput
+	if err := c.ShouldBindJSON(&in); err != nil || len(in.Words) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "words are required"})
+		return
+	}
+
+	lang, err := utils.ParseLang(in.Lang)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	kind := strings.ToLower(strings.TrimSpace(in.Kind))
+	if kind == "" {
+		kind = "word"
+	}
+	if !validStopwordKind(kind) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be word or phrase"})
+		return
+	}
+
+	rows, err := repositories.AddStopwords(c.Request.Context(), lang, kind, in.Words)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add stopwords"})
+		return
+	}
+	// the word counts of every cached range change
+	middlewares.DefaultResponseCache.Purge()
+	c.JSON(http.StatusCreated, rows)
+}
+
+// DeleteStopword DELETE /admin/stopwords?word=foo[&lang=hun&kind=word]
+func DeleteStopword(c *gin.Context) {
+	word := strings.TrimSpace(c.Query("word"))
+	if word == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "word parameter is required"})
+		return
+	}
+	lang, ok := langParam(c)
+	if !ok {
+		return
+	}
+	kind := strings.ToLower(c.DefaultQuery("kind", "word"))
+	if !validStopwordKind(kind) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be word or phrase"})
+		return
+	}
+
+	err := repositories.DeleteStopword(c.Request.Context(), lang, word, kind)
+	if errors.Is(err, repositories.ErrStopwordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "stopword not found"})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete stopword"})
+		return
+	}
+	middlewares.DefaultResponseCache.Purge()
+	c.Status(http.StatusNoContent)
+}
+
+func validStopwordKind(kind string) bool {
+	return kind == "word" || kind == "phrase"
+}
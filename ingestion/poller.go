@@ -0,0 +1,268 @@
+// Package ingestion polls the RSS feeds of the sources, stores new feeds
+// and scores their sentiment with the configured SentimentAnalyzer.
+package ingestion
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+)
+
+// Store is where the poller reads its sources and writes feeds and scores.
+type Store interface {
+	ListFeedSources(ctx context.Context) ([]models.FeedSource, error)
+	InsertFeed(ctx context.Context, f models.NewFeed) (id int, inserted bool, err error)
+	UpsertFeedSentiments(ctx context.Context, rows []models.NewFeedSentiment) error
+	MarkSourcePolled(ctx context.Context, sourceID int) error
+}
+
+// repositoryStore is the Store of the database.
+type repositoryStore struct{}
+
+func (repositoryStore) ListFeedSources(ctx context.Context) ([]models.FeedSource, error) {
+	return repositories.ListFeedSources(ctx)
+}
+
+func (repositoryStore) InsertFeed(ctx context.Context, f models.NewFeed) (int, bool, error) {
+	return repositories.InsertFeed(ctx, f)
+}
+
+func (repositoryStore) UpsertFeedSentiments(ctx context.Context, rows []models.NewFeedSentiment) error {
+	return repositories.UpsertFeedSentiments(ctx, rows)
+}
+
+func (repositoryStore) MarkSourcePolled(ctx context.Context, sourceID int) error {
+	return repositories.MarkSourcePolled(ctx, sourceID)
+}
+
+// Poller fetches every configured source on a fixed interval.
+type Poller struct {
+	HTTPClient  *http.Client
+	Sentiment   utils.SentimentAnalyzer
+	Store       Store
+	ModelID     int           // feed_sentiments.model_id the scores are stored under
+	Interval    time.Duration // time between polling rounds
+	BatchSize   int           // titles per BatchAnalyze call
+	CallTimeout time.Duration // deadline of a single BatchAnalyze call
+
+	// OnInsert, if set, is called with the feed_date range (YYYY-MM-DD) of
+	// the feeds a source added, once they are scored.
+	OnInsert func(from, to string)
+}
+
+// NewPoller returns a Poller with sane defaults.
+func NewPoller(analyzer utils.SentimentAnalyzer, modelID int, interval time.Duration) *Poller {
+	return &Poller{
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+		Sentiment:   analyzer,
+		Store:       repositoryStore{},
+		ModelID:     modelID,
+		Interval:    interval,
+		BatchSize:   50,
+		CallTimeout: 30 * time.Second,
+	}
+}
+
+// Run polls immediately and then on every Interval until ctx is done.
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := p.PollOnce(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "polling failed", slog.Any("error", err))
+		} else {
+			slog.InfoContext(ctx, "polled", slog.Int("sources", stats.Sources), slog.Int("fetched", stats.Fetched),
+				slog.Int("inserted", stats.Inserted), slog.Int("duplicates", stats.Duplicates),
+				slog.Int("scored", stats.Scored), slog.Int("failed", stats.Failed))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PollOnce runs one polling round over every source. A failing source is
+// counted and logged, it does not stop the round.
+func (p *Poller) PollOnce(ctx context.Context) (models.IngestStats, error) {
+	var total models.IngestStats
+
+	sources, err := p.Store.ListFeedSources(ctx)
+	if err != nil {
+		return total, err
+	}
+
+	for _, src := range sources {
+		stats, err := p.PollSource(ctx, src)
+		total.Sources++
+		total.Fetched += stats.Fetched
+		total.Inserted += stats.Inserted
+		total.Duplicates += stats.Duplicates
+		total.Scored += stats.Scored
+		total.Failed += stats.Failed
+		if err != nil {
+			total.Failed++
+			slog.ErrorContext(ctx, "source polling failed", slog.Int("source_id", src.ID),
+				slog.String("source", src.Name), slog.Any("error", err))
+		}
+	}
+	return total, nil
+}
+
+// PollSource fetches one source, inserts its new feeds and scores them.
+func (p *Poller) PollSource(ctx context.Context, src models.FeedSource) (models.IngestStats, error) {
+	var stats models.IngestStats
+
+	items, err := utils.FetchFeed(ctx, p.HTTPClient, src.RSSURL)
+	if err != nil {
+		return stats, err
+	}
+	stats.Fetched = len(items)
+
+	var inserted []models.NewFeed
+	var ids []int
+	for _, f := range BuildFeeds(src, items, time.Now()) {
+		id, ok, err := p.Store.InsertFeed(ctx, f)
+		if err != nil {
+			return stats, err
+		}
+		if !ok {
+			stats.Duplicates++
+			continue
+		}
+		stats.Inserted++
+		inserted = append(inserted, f)
+		ids = append(ids, id)
+	}
+
+	// while the service is known to be down the feeds are only stored
+	if a, ok := p.Sentiment.(interface{ Available() bool }); ok && !a.Available() && len(inserted) > 0 {
+		stats.Failed += len(inserted)
+		slog.WarnContext(ctx, "sentiment service unavailable, feeds left unscored",
+			slog.Int("source_id", src.ID), slog.Int("feeds", len(inserted)))
+		p.notifyInsert(inserted)
+		return stats, p.Store.MarkSourcePolled(ctx, src.ID)
+	}
+
+	for start := 0; start < len(inserted); start += p.BatchSize {
+		end := min(start+p.BatchSize, len(inserted))
+		scored, err := p.score(ctx, src.Lang, inserted[start:end], ids[start:end])
+		if err != nil {
+			// the feeds are stored; a backfill can score them later
+			stats.Failed += end - start
+			slog.WarnContext(ctx, "scoring failed", slog.Int("source_id", src.ID),
+				slog.Int("feeds", end-start), slog.Any("error", err))
+			continue
+		}
+		stats.Scored += scored
+	}
+	p.notifyInsert(inserted)
+
+	return stats, p.Store.MarkSourcePolled(ctx, src.ID)
+}
+
+func (p *Poller) notifyInsert(feeds []models.NewFeed) {
+	if p.OnInsert == nil || len(feeds) == 0 {
+		return
+	}
+	from, to := feeds[0].FeedDate, feeds[0].FeedDate
+	for _, f := range feeds[1:] {
+		from, to = min(from, f.FeedDate), max(to, f.FeedDate)
+	}
+	p.OnInsert(from, to)
+}
+
+// score analyzes a batch of feeds and stores the results.
+func (p *Poller) score(ctx context.Context, lang string, feeds []models.NewFeed, ids []int) (int, error) {
+	in := make([]utils.SentimentInput, len(feeds))
+	for i, f := range feeds {
+		in[i] = utils.SentimentInput{Text: f.Title, Lang: lang}
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, p.CallTimeout)
+	defer cancel()
+
+	results, err := p.Sentiment.BatchAnalyze(callCtx, in)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := make([]models.NewFeedSentiment, len(feeds))
+	for i, r := range results {
+		rows[i] = SentimentRow(ids[i], p.ModelID, feeds[i].FeedDate, r)
+	}
+	if err := p.Store.UpsertFeedSentiments(ctx, rows); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// SentimentRow converts an analyzer result into a feed_sentiments row.
+func SentimentRow(feedID, modelID int, feedDate string, r utils.SentimentResult) models.NewFeedSentiment {
+	return models.NewFeedSentiment{
+		FeedID:            feedID,
+		ModelID:           modelID,
+		FeedDate:          feedDate,
+		SentimentKey:      r.Key,
+		SentimentValue:    r.Value,
+		SentimentCompound: r.Compound(),
+		Sentiments:        r.Scores,
+	}
+}
+
+// BuildFeeds turns parsed feed items into feeds rows. Items without a title
+// or link are dropped; items without a date are dated now. Duplicates within
+// the same fetch are dropped as well.
+func BuildFeeds(src models.FeedSource, items []utils.FeedItem, now time.Time) []models.NewFeed {
+	out := make([]models.NewFeed, 0, len(items))
+	seen := map[string]struct{}{}
+
+	for _, item := range items {
+		title := item.Title
+		if item.Source != "" {
+			title = strings.TrimSuffix(title, " - "+item.Source)
+		}
+		link := item.Link
+		if link == "" && strings.HasPrefix(item.GUID, "http") {
+			link = item.GUID // permalink GUIDs
+		}
+		if title == "" || link == "" {
+			continue
+		}
+
+		published := item.Published
+		if published.IsZero() {
+			published = now
+		}
+		feedDate := published.Format("2006-01-02")
+
+		key := link + "|" + feedDate
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		words, masked := utils.TokenizeTitle(title)
+		out = append(out, models.NewFeed{
+			SourceID:    src.ID,
+			Title:       title,
+			Link:        link,
+			Lang:        src.Lang,
+			Words:       words,
+			WordsMasked: masked,
+			Published:   published,
+			FeedDate:    feedDate,
+		})
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+package ingestion
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang-restapi/models"
+	"golang-restapi/sentimentpb"
+	"golang-restapi/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const fixtureRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel>
+<item><title>Új kormányrendelet jelent meg - Index</title><link>https://index.hu/1</link><source>Index</source><pubDate>Tue, 14 Oct 2025 08:30:00 +0200</pubDate></item>
+<item><title>Tragédia a Balatonon</title><link>https://index.hu/2</link><pubDate>Wed, 15 Oct 2025 10:00:00 +0200</pubDate></item>
+<item><title>Már megvolt</title><link>https://index.hu/3</link><pubDate>Wed, 15 Oct 2025 11:00:00 +0200</pubDate></item>
+</channel></rss>`
+
+func TestBuildFeeds(t *testing.T) {
+	now := time.Date(2025, 10, 16, 12, 0, 0, 0, time.UTC)
+	published := time.Date(2025, 10, 14, 8, 30, 0, 0, time.UTC)
+	src := models.FeedSource{ID: 7, Lang: "hun"}
+	items := []utils.FeedItem{
+		{Title: "Első cím - Index", Link: "https://index.hu/1", Source: "Index", Published: published},
+		{Title: "Első cím - Index", Link: "https://index.hu/1", Source: "Index", Published: published}, // same fetch
+		{Title: "Permalink GUID", GUID: "https://telex.hu/2"},
+		{Title: "", Link: "https://index.hu/3"},
+		{Title: "Nincs link", GUID: "urn:uuid:1"},
+	}
+
+	feeds := BuildFeeds(src, items, now)
+	if len(feeds) != 2 {
+		t.Fatalf("got %d feeds, want 2: %+v", len(feeds), feeds)
+	}
+
+	f := feeds[0]
+	if f.Title != "Első cím" || f.Link != "https://index.hu/1" || f.SourceID != 7 || f.Lang != "hun" {
+		t.Errorf("feed 0 = %+v", f)
+	}
+	if f.FeedDate != "2025-10-14" || !f.Published.Equal(published) {
+		t.Errorf("feed 0 dated %s / %s", f.FeedDate, f.Published)
+	}
+	if strings.Join(f.Words, " ") != "első cím" {
+		t.Errorf("feed 0 words = %q", f.Words)
+	}
+
+	f = feeds[1]
+	if f.Link != "https://telex.hu/2" {
+		t.Errorf("feed 1 link = %q, want the permalink GUID", f.Link)
+	}
+	if f.FeedDate != "2025-10-16" || !f.Published.Equal(now) {
+		t.Errorf("feed 1 without a date dated %s / %s, want now", f.FeedDate, f.Published)
+	}
+}
+
+func TestPollSource(t *testing.T) {
+	feed := serveFeed(t, fixtureRSS)
+	sentiment := &fakeSentimentServer{}
+	store := newMemoryStore("https://index.hu/3")
+
+	p := NewPoller(utils.GRPCAnalyzer{Client: dialSentiment(t, sentiment)}, 2, time.Minute)
+	p.HTTPClient = feed.Client()
+	p.Store = store
+	p.BatchSize = 1
+	var from, to string
+	p.OnInsert = func(f, t string) { from, to = f, t }
+
+	stats, err := p.PollSource(context.Background(), models.FeedSource{ID: 3, Name: "Index", Lang: "hun", RSSURL: feed.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := models.IngestStats{Fetched: 3, Inserted: 2, Duplicates: 1, Scored: 2}
+	if stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+	if sentiment.calls != 2 {
+		t.Errorf("BatchAnalyze called %d times, want 2 (BatchSize 1)", sentiment.calls)
+	}
+	if len(store.feeds) != 3 || store.feeds[1].Title != "Új kormányrendelet jelent meg" {
+		t.Errorf("stored feeds = %+v", store.feeds)
+	}
+	if len(store.sentiments) != 2 {
+		t.Fatalf("stored %d sentiments, want 2", len(store.sentiments))
+	}
+	for _, s := range store.sentiments {
+		if s.ModelID != 2 || s.SentimentKey != "negative" || s.SentimentCompound != -0.5 {
+			t.Errorf("sentiment row = %+v", s)
+		}
+	}
+	if store.sentiments[0].FeedDate != "2025-10-14" || store.sentiments[1].FeedDate != "2025-10-15" {
+		t.Errorf("sentiment dates = %s, %s", store.sentiments[0].FeedDate, store.sentiments[1].FeedDate)
+	}
+	if from != "2025-10-14" || to != "2025-10-15" {
+		t.Errorf("OnInsert(%q, %q), want 2025-10-14..2025-10-15", from, to)
+	}
+	if store.polled[3] != 1 {
+		t.Errorf("source marked polled %d times, want 1", store.polled[3])
+	}
+}
+
+func TestPollSourceKeepsFeedsWhenScoringFails(t *testing.T) {
+	feed := serveFeed(t, fixtureRSS)
+	sentiment := &fakeSentimentServer{err: status.Error(codes.Unavailable, "model loading")}
+	store := newMemoryStore()
+
+	p := NewPoller(utils.GRPCAnalyzer{Client: dialSentiment(t, sentiment)}, 1, time.Minute)
+	p.HTTPClient = feed.Client()
+	p.Store = store
+
+	stats, err := p.PollSource(context.Background(), models.FeedSource{ID: 3, Lang: "hun", RSSURL: feed.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Inserted != 3 || stats.Scored != 0 || stats.Failed != 3 {
+		t.Errorf("stats = %+v, want 3 inserted and 3 failed", stats)
+	}
+	if len(store.sentiments) != 0 {
+		t.Errorf("stored %d sentiments, want none", len(store.sentiments))
+	}
+	if store.polled[3] != 1 {
+		t.Errorf("source marked polled %d times, want 1", store.polled[3])
+	}
+}
+
+func TestPollSourceFeedError(t *testing.T) {
+	feed := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(feed.Close)
+	store := newMemoryStore()
+
+	p := NewPoller(utils.FakeAnalyzer{}, 1, time.Minute)
+	p.Store = store
+	if _, err := p.PollSource(context.Background(), models.FeedSource{ID: 3, RSSURL: feed.URL}); err == nil {
+		t.Fatal("PollSource of a 404 feed succeeded")
+	}
+	if store.polled[3] != 0 {
+		t.Error("a failed source was marked polled")
+	}
+}
+
+func serveFeed(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// fakeSentimentServer scores every text negative, or fails with err.
+type fakeSentimentServer struct {
+	sentimentpb.UnimplementedSentimentServiceServer
+	err   error
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *fakeSentimentServer) BatchAnalyze(_ context.Context, in *sentimentpb.BatchAnalyzeRequest) (*sentimentpb.BatchAnalyzeResponse, error) {
+	s.mu.Lock()
+	s.calls++
+	s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	resp := &sentimentpb.BatchAnalyzeResponse{}
+	for _, item := range in.GetItems() {
+		resp.Results = append(resp.Results, &sentimentpb.AnalyzeResponse{
+			Title:          item.GetText(),
+			SentimentKey:   "NEGATIVE",
+			SentimentValue: 0.75,
+			Sentiments:     map[string]float64{"negative": 0.75, "neutral": 0.25, "compound": -0.5},
+		})
+	}
+	return resp, nil
+}
+
+// dialSentiment serves s in memory and returns a client connected to it.
+func dialSentiment(t *testing.T, s sentimentpb.SentimentServiceServer) sentimentpb.SentimentServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	sentimentpb.RegisterSentimentServiceServer(srv, s)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return sentimentpb.NewSentimentServiceClient(conn)
+}
+
+// memoryStore is a Store keeping rows in memory; links passed to
+// newMemoryStore already exist.
+type memoryStore struct {
+	feeds      []models.NewFeed
+	sentiments []models.NewFeedSentiment
+	polled     map[int]int
+}
+
+func newMemoryStore(existing ...string) *memoryStore {
+	s := &memoryStore{polled: map[int]int{}}
+	for _, link := range existing {
+		s.feeds = append(s.feeds, models.NewFeed{Link: link})
+	}
+	return s
+}
+
+func (s *memoryStore) ListFeedSources(context.Context) ([]models.FeedSource, error) {
+	return nil, nil
+}
+
+func (s *memoryStore) InsertFeed(_ context.Context, f models.NewFeed) (int, bool, error) {
+	for _, old := range s.feeds {
+		if old.Link == f.Link {
+			return 0, false, nil
+		}
+	}
+	s.feeds = append(s.feeds, f)
+	return len(s.feeds), true, nil
+}
+
+func (s *memoryStore) UpsertFeedSentiments(_ context.Context, rows []models.NewFeedSentiment) error {
+	s.sentiments = append(s.sentiments, rows...)
+	return nil
+}
+
+func (s *memoryStore) MarkSourcePolled(_ context.Context, sourceID int) error {
+	s.polled[sourceID]++
+	return nil
+}
@@ -1,40 +1,241 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"net"
+	"os"
+	"time"
 
+	"golang-restapi/analytics"
+	"golang-restapi/analyticspb"
+	"golang-restapi/backfill"
 	"golang-restapi/config"
 	"golang-restapi/db"
+	"golang-restapi/ingestion"
+	"golang-restapi/metrics"
 	"golang-restapi/middlewares"
+	"golang-restapi/partitions"
+	"golang-restapi/repositories"
 	"golang-restapi/routes"
+	"golang-restapi/tracing"
 	"golang-restapi/utils"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
 	// Load config & set Gin mode
 	cfg := config.LoadConfig()
+	utils.InitLogger(os.Stderr, cfg.LogLevel, cfg.LogFormat)
 	//gin.SetMode(gin.DebugMode)
 
+	// subcommands (migrate, ...) run instead of the server
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(cfg, os.Args[1], os.Args[2:]))
+	}
+
+	if cfg.JWTSecret == "" && len(cfg.AuthIssuers) == 0 {
+		log.Fatal("JWT_SECRET_KEY or ALLOWED_ISSUERS is required")
+	}
+	// RS256/ES256 tokens of the issuers are only accepted for our audiences
+	if len(cfg.AuthIssuers) > 0 && len(cfg.AuthAudiences) == 0 {
+		if cfg.JWTSecret == "" {
+			log.Fatal("AUTH_AUDIENCES is required with ALLOWED_ISSUERS")
+		}
+		log.Println("AUTH_AUDIENCES is not set: RS256/ES256 tokens of ALLOWED_ISSUERS are rejected")
+	}
+
+	// OpenTelemetry spans for requests, SQL statements and gRPC calls
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.TracingExporter, cfg.TracingEndpoint)
+	if err != nil {
+		log.Fatalf("invalid tracing settings: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to flush traces", slog.Any("error", err))
+		}
+	}()
+
 	// Init DB
 	db.InitDB(cfg)
 	defer db.DB.Close()
+	metrics.RegisterDB(db.DB, cfg.DBName)
+
+	// gRPC sentiment client
+	sentiment, err := utils.NewSentimentClient(utils.SentimentClientConfig{
+		Addr:             cfg.SentimentAddr,
+		TLS:              cfg.SentimentTLS,
+		TLSCAFile:        cfg.SentimentTLSCAFile,
+		TLSServerName:    cfg.SentimentTLSServerName,
+		Timeout:          cfg.SentimentTimeout,
+		Retries:          cfg.SentimentRetries,
+		RetryBackoff:     200 * time.Millisecond,
+		BreakerThreshold: cfg.SentimentBreakerThreshold,
+		BreakerCooldown:  cfg.SentimentBreakerCooldown,
+	})
+	if err != nil {
+		log.Fatalf("failed to create sentiment client: %v", err)
+	}
+	defer sentiment.Close()
+
+	analyzer, err := utils.NewSentimentAnalyzer(cfg.SentimentBackend, sentiment)
+	if err != nil {
+		log.Fatalf("invalid sentiment backend: %v", err)
+	}
+
+	// cache of repeated titles, in memory and optionally in sentiment_cache
+	if cfg.SentimentCacheSize > 0 || cfg.SentimentCacheDB {
+		cacheCfg := utils.SentimentCacheConfig{
+			Size:         cfg.SentimentCacheSize,
+			TTL:          cfg.SentimentCacheTTL,
+			ModelVersion: cfg.SentimentBackend + ":" + cfg.SentimentModelVersion,
+		}
+		if cfg.SentimentCacheDB {
+			store := repositories.SentimentCache{ModelVersion: cacheCfg.ModelVersion, TTL: cacheCfg.TTL}
+			if n, err := store.Purge(context.Background()); err != nil {
+				slog.Error("failed to purge sentiment cache", slog.Any("error", err))
+			} else if n > 0 {
+				slog.Info("purged stale sentiment cache rows", slog.Int64("rows", n))
+			}
+			cacheCfg.Store = store
+		}
+		analyzer = utils.NewCachedAnalyzer(analyzer, cacheCfg)
+	}
+	utils.DefaultAnalyzer = analyzer
+
+	// optional lemma dictionary for normalize=lemma
+	if cfg.LemmaDictPath != "" {
+		if err := utils.InitLemmatizer(cfg.LemmaDictPath); err != nil {
+			log.Fatalf("failed to load lemma dictionary: %v", err)
+		}
+	}
 
-	// init gRPC client
-	utils.InitSentimentClient("localhost:50051")
-	defer utils.CloseSentimentClient()
+	// monthly partitions of feeds and feed_sentiments, created ahead of the data
+	if cfg.PartitionsEnabled {
+		manager, err := partitions.NewManager(cfg.PartitionMonthsAhead, cfg.PartitionRetentionMonths,
+			cfg.PartitionRetentionMode, cfg.PartitionInterval)
+		if err != nil {
+			log.Fatalf("invalid partition settings: %v", err)
+		}
+		if _, err := manager.Maintain(context.Background(), time.Now()); err != nil {
+			slog.Error("partition maintenance failed", slog.Any("error", err))
+		}
+		go manager.Run(context.Background())
+	}
+
+	// cached analytics responses, dropped when ingestion adds feeds
+	if cfg.ResponseCacheSize > 0 {
+		middlewares.DefaultResponseCache = middlewares.NewResponseCache(middlewares.ResponseCacheConfig{
+			Size:      cfg.ResponseCacheSize,
+			PastTTL:   cfg.ResponseCachePastTTL,
+			RecentTTL: cfg.ResponseCacheRecentTTL,
+		})
+	}
+
+	// ranges written by the sync command, which runs in its own process
+	if rc := middlewares.DefaultResponseCache; rc != nil {
+		go repositories.ListenResponseCache(context.Background(), db.DSN(cfg), rc.Invalidate)
+	}
+
+	// RSS ingestion worker
+	if cfg.IngestEnabled {
+		poller := ingestion.NewPoller(analyzer, cfg.IngestModelID, cfg.IngestInterval)
+		if rc := middlewares.DefaultResponseCache; rc != nil {
+			poller.OnInsert = rc.Invalidate
+		}
+		go poller.Run(context.Background())
+	}
+
+	// sentiment backfill jobs; jobs interrupted by a restart continue
+	runner, err := backfill.NewRunner(analyzer,
+		cfg.BackfillBatchSize, cfg.BackfillConcurrency, cfg.BackfillRetries)
+	if err != nil {
+		log.Fatalf("invalid backfill settings: %v", err)
+	}
+	if rc := middlewares.DefaultResponseCache; rc != nil {
+		runner.OnWrite = rc.Invalidate
+	}
+	backfill.DefaultRunner = runner
+	if err := backfill.DefaultRunner.ResumeInterrupted(context.Background()); err != nil {
+		slog.Error("failed to resume backfill jobs", slog.Any("error", err))
+	}
+
+	// authentication and rate limits, shared by the HTTP API and gRPC
+	auth := middlewares.NewAuthenticator(cfg, middlewares.NewJWKS(cfg.AuthJWKSRefresh))
+	var limiter *middlewares.RateLimiter
+	if cfg.RateLimitEnabled && cfg.RateLimitCapacity > 0 {
+		store, err := middlewares.NewRateLimitStore(cfg.RateLimitBackend)
+		if err != nil {
+			log.Fatalf("invalid rate limit settings: %v", err)
+		}
+		limiter = middlewares.NewRateLimiter(store, cfg.RateLimitCapacity)
+		limiter.IPCapacity = cfg.RateLimitIPCapacity
+	}
+
+	// analytics gRPC server next to the HTTP API, behind the same auth.
+	// Credentials travel in its metadata: without TLS it only listens on
+	// loopback, for a sidecar or a local client.
+	if cfg.GRPCEnabled {
+		grpcAuth := analytics.Auth{Authenticator: auth, Limiter: limiter}
+		opts := []grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+			grpc.ChainUnaryInterceptor(utils.LoggingUnaryServerInterceptor, grpcAuth.UnaryInterceptor),
+			grpc.ChainStreamInterceptor(utils.LoggingStreamServerInterceptor, grpcAuth.StreamInterceptor),
+		}
+		addr := "127.0.0.1:" + cfg.GRPCPort
+		if cfg.GRPCTLSCertFile != "" || cfg.GRPCTLSKeyFile != "" {
+			creds, err := credentials.NewServerTLSFromFile(cfg.GRPCTLSCertFile, cfg.GRPCTLSKeyFile)
+			if err != nil {
+				log.Fatalf("invalid gRPC TLS settings: %v", err)
+			}
+			opts = append(opts, grpc.Creds(creds))
+			addr = ":" + cfg.GRPCPort
+		}
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			log.Fatalf("failed to listen on gRPC port: %v", err)
+		}
+		grpcServer := grpc.NewServer(opts...)
+		analyticspb.RegisterAnalyticsServiceServer(grpcServer, analytics.NewServer())
+		healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+		if cfg.GRPCReflection {
+			reflection.Register(grpcServer)
+		}
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("gRPC server failed: %v", err)
+			}
+		}()
+		defer grpcServer.GracefulStop()
+	}
 
 	// router init
 	router := gin.New()
+	// the client IP keys rate limits and logs: only believe X-Forwarded-For
+	// from our own proxies, and not at all without one
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
 	router.Use(
-		gin.Logger(),
+		middlewares.RequestLogger(),
+		middlewares.Tracing(),
+		middlewares.Metrics(),
 		gin.Recovery(),
 		middlewares.ErrorLogger(),
 	)
 
 	// Register your routes
-	routes.SetupRoutes(router, cfg)
+	routes.SetupRoutes(router, cfg, auth, limiter)
 
 	if err := router.Run(":" + cfg.APP_PORT); err != nil {
 		log.Fatalf("Server failed to start: %v", err)
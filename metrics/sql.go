@@ -0,0 +1,315 @@
+package metrics
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const modulePrefix = "golang-restapi/"
+
+var tracer = otel.Tracer(modulePrefix + "db")
+
+// InstrumentConnector times every statement run on the connector's connections
+// and labels it with the repository function that issued it, so call sites
+// stay plain database/sql:
+//
+//	db.DB = sql.OpenDB(metrics.InstrumentConnector(connector))
+//
+// Statements run with a traced context also get a child span named after
+// that function, with the SQL text and the number of rows.
+func InstrumentConnector(c driver.Connector) driver.Connector {
+	return &connector{Connector: c}
+}
+
+type connector struct {
+	driver.Connector
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: cn}, nil
+}
+
+// statement is one SQL call being observed.
+type statement struct {
+	fn    string
+	start time.Time
+	span  trace.Span // nil unless ctx was traced
+}
+
+// begin starts observing query. Background work without a trace (the
+// ingestion poller, partition maintenance) gets no span.
+func begin(ctx context.Context, query string) *statement {
+	s := &statement{fn: caller(), start: time.Now()}
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		_, s.span = tracer.Start(ctx, s.fn,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemNamePostgreSQL,
+				semconv.DBQueryText(query),
+				semconv.CodeFunctionName(s.fn),
+			))
+	}
+	return s
+}
+
+// done records the duration and error of the statement. The span stays
+// open until end: for queries, until their rows are closed.
+func (s *statement) done(err error) {
+	DBQueryDuration.WithLabelValues(s.fn).Observe(time.Since(s.start).Seconds())
+	if err == nil || errors.Is(err, driver.ErrSkip) {
+		return
+	}
+	DBQueryErrors.WithLabelValues(s.fn).Inc()
+	if s.span != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+func (s *statement) end(attrs ...attribute.KeyValue) {
+	if s.span != nil {
+		s.span.SetAttributes(attrs...)
+		s.span.End()
+	}
+}
+
+// exec finishes an ExecContext call.
+func (s *statement) exec(res driver.Result, err error) (driver.Result, error) {
+	s.done(err)
+	if err != nil {
+		s.end()
+		return res, err
+	}
+	if n, rerr := res.RowsAffected(); rerr == nil {
+		s.end(attribute.Int64("db.response.affected_rows", n))
+	} else {
+		s.end()
+	}
+	return res, nil
+}
+
+// query finishes a QueryContext call; the span ends with the rows.
+func (s *statement) query(r driver.Rows, err error) (driver.Rows, error) {
+	s.done(err)
+	if err != nil {
+		s.end()
+		return r, err
+	}
+	if s.span == nil {
+		return r, nil
+	}
+	return &rows{Rows: r, st: s}, nil
+}
+
+// caller returns the first exported repositories function on the stack,
+// else the first function of this module outside this package, e.g.
+// "repositories.GetFeeds" or "db.runMigration".
+func caller() string {
+	pcs := make([]uintptr, 24)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	fallback := "unknown"
+	for {
+		f, more := frames.Next()
+		if strings.HasPrefix(f.Function, modulePrefix) && !strings.HasPrefix(f.Function, modulePrefix+"metrics.") {
+			name := strings.TrimPrefix(f.Function, modulePrefix)
+			if pkg, fn, ok := strings.Cut(name, "."); ok {
+				fn, _, _ = strings.Cut(fn, ".") // closures: GetFeeds.func1
+				if pkg == "repositories" && fn != "" && unicode.IsUpper(rune(fn[0])) {
+					return pkg + "." + fn
+				}
+				if fallback == "unknown" {
+					fallback = pkg + "." + fn
+				}
+			}
+		}
+		if !more {
+			return fallback
+		}
+	}
+}
+
+// conn wraps a driver connection. lib/pq implements every optional
+// interface used here.
+type conn struct {
+	driver.Conn
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	st := begin(ctx, query)
+	return st.query(q.QueryContext(ctx, query, args))
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	st := begin(ctx, query)
+	return st.exec(e.ExecContext(ctx, query, args))
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var st driver.Stmt
+	var err error
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		st, err = p.PrepareContext(ctx, query)
+	} else {
+		st, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: st, query: query}, nil
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// stmt wraps a prepared statement, as used by the batch upserts.
+type stmt struct {
+	driver.Stmt
+	query string
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	st := begin(ctx, s.query)
+	var res driver.Result
+	var err error
+	if e, ok := s.Stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		var vals []driver.Value
+		if vals, err = namedToValues(args); err == nil {
+			res, err = s.Stmt.Exec(vals)
+		}
+	}
+	return st.exec(res, err)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	st := begin(ctx, s.query)
+	var rows driver.Rows
+	var err error
+	if q, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		var vals []driver.Value
+		if vals, err = namedToValues(args); err == nil {
+			rows, err = s.Stmt.Query(vals)
+		}
+	}
+	return st.query(rows, err)
+}
+
+func namedToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	vals := make([]driver.Value, len(args))
+	for i, a := range args {
+		if a.Name != "" {
+			return nil, errors.New("metrics: named parameters are not supported")
+		}
+		vals[i] = a.Value
+	}
+	return vals, nil
+}
+
+// rows counts the rows read, for the span of a traced query.
+type rows struct {
+	driver.Rows
+	st *statement
+	n  int64
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.n++
+	}
+	return err
+}
+
+func (r *rows) Close() error {
+	err := r.Rows.Close()
+	r.st.end(attribute.Int64("db.response.returned_rows", r.n))
+	return err
+}
+
+// The column type methods of lib/pq, for sql.Rows.ColumnTypes.
+
+func (r *rows) ColumnTypeScanType(i int) reflect.Type {
+	if t, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return t.ColumnTypeScanType(i)
+	}
+	return reflect.TypeFor[any]()
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(i int) string {
+	if t, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return t.ColumnTypeDatabaseTypeName(i)
+	}
+	return ""
+}
+
+func (r *rows) ColumnTypeLength(i int) (int64, bool) {
+	if t, ok := r.Rows.(driver.RowsColumnTypeLength); ok {
+		return t.ColumnTypeLength(i)
+	}
+	return 0, false
+}
+
+func (r *rows) ColumnTypePrecisionScale(i int) (int64, int64, bool) {
+	if t, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return t.ColumnTypePrecisionScale(i)
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+	"golang-restapi/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries an API key instead of a bearer token.
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyContextKey holds the models.APIKey of a request authenticated by key.
+const apiKeyContextKey = "api_key"
+
+// authenticateAPIKey resolves the key and counts its use. Its RateLimit is
+// applied by the RateLimiter.
+func (a *Authenticator) authenticateAPIKey(ctx context.Context, key string) (models.APIKey, *AuthError) {
+	k, err := repositories.UseAPIKey(ctx, utils.HashAPIKey(key))
+	if errors.Is(err, repositories.ErrAPIKeyNotFound) {
+		return k, unauthorized("Invalid API key.")
+	}
+	if err != nil {
+		return k, &AuthError{Status: http.StatusInternalServerError, Detail: "Failed to verify API key.", Err: err}
+	}
+	return k, nil
+}
+
+// RequireScope rejects API keys without scope. JWT users are not scoped.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if v, ok := c.Get(apiKeyContextKey); ok {
+			if k, _ := v.(models.APIKey); !k.HasScope(scope) {
+				c.JSON(http.StatusForbidden, gin.H{"detail": "API key lacks the " + scope + " scope."})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -1,108 +1,231 @@
 package middlewares
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
+	"slices"
 	"strings"
-	"time"
+
+	"golang-restapi/config"
+	"golang-restapi/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/joho/godotenv"
 )
 
-// Authentication - JWT authentication
-func Authentication() gin.HandlerFunc {
-	// Load .env and secret key
-	godotenv.Load()
-	jwtSecret := []byte(strings.TrimSpace(os.Getenv("JWT_SECRET_KEY")))
+// AuthError is a rejected credential: the HTTP status and the detail
+// returned to the client.
+type AuthError struct {
+	Status int
+	Detail string
+	Err    error // cause of a 500, logged but not returned
+}
+
+func (e *AuthError) Error() string {
+	if e.Err != nil {
+		return e.Detail + ": " + e.Err.Error()
+	}
+	return e.Detail
+}
+
+// Identity is an authenticated caller: a JWT user, or an API key.
+type Identity struct {
+	Email  string
+	Issuer string
+	Roles  []string
+	APIKey *models.APIKey
+}
+
+// HasScope reports whether the caller may use routes of scope. JWT users
+// are not scoped.
+func (id Identity) HasScope(scope string) bool {
+	return id.APIKey == nil || id.APIKey.HasScope(scope)
+}
+
+// Authenticator checks bearer tokens and API keys, for the HTTP middleware
+// and the gRPC interceptors of the analytics service.
+//
+// A request with an X-API-Key header is authenticated by key. HS256 tokens
+// are checked against cfg.JWTSecret; RS256/ES256 tokens against the JWKS of
+// their issuer, which must be in cfg.AuthIssuers, and must carry
+// `email_verified`. `email` must be in cfg.AuthEmails (case-insensitive)
+// and `aud` in cfg.AuthAudiences; an empty allow-list accepts any value. Roles come from the cfg.AuthRolesClaim claim
+// (see RequireRole). RS256/ES256 are only accepted when keys is set and
+// cfg.AuthAudiences is not empty: an issuer such as Google signs tokens for
+// every client, and without an audience check any of them would do.
+type Authenticator struct {
+	jwtSecret  []byte
+	keys       *JWKS
+	issuers    []string
+	audiences  []string
+	emails     []string // lowercased
+	rolesClaim string
+	parser     *jwt.Parser
+}
+
+func NewAuthenticator(cfg config.Config, keys *JWKS) *Authenticator {
+	a := &Authenticator{
+		jwtSecret:  []byte(cfg.JWTSecret),
+		keys:       keys,
+		issuers:    cfg.AuthIssuers,
+		audiences:  cfg.AuthAudiences,
+		rolesClaim: cfg.AuthRolesClaim,
+	}
+	for _, e := range cfg.AuthEmails {
+		a.emails = append(a.emails, strings.ToLower(e))
+	}
 
+	var methods []string
+	if len(a.jwtSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if a.AcceptsIssuerKeys() {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	a.parser = jwt.NewParser(
+		jwt.WithValidMethods(methods),
+		jwt.WithLeeway(cfg.AuthClockSkew),
+		jwt.WithIssuedAt(),
+	)
+	return a
+}
+
+// AcceptsIssuerKeys reports whether RS256/ES256 tokens signed by the
+// JWKS of the allowed issuers are accepted.
+func (a *Authenticator) AcceptsIssuerKeys() bool {
+	return a.keys != nil && len(a.issuers) > 0 && len(a.audiences) > 0
+}
+
+// Authentication authenticates every request by API key or bearer token
+// and puts the caller into the context ("email", "issuer", "roles", or the
+// API key).
+func Authentication(a *Authenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-
-		// // Skip auth for Swagger/OpenAPI docs
-		// if strings.HasPrefix(c.Request.URL.Path, "/docs") ||
-		// 	c.Request.URL.Path == "/openapi.json" ||
-		// 	c.Request.URL.Path == "/swagger.json" {
-		// 	c.Next()
-		// 	return
-		// }
-
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"detail": "Authorization token is missing"})
+		id, aerr := a.Authenticate(c.Request.Context(), c.GetHeader("Authorization"), c.GetHeader(APIKeyHeader))
+		if aerr != nil {
+			if aerr.Err != nil {
+				c.Error(aerr.Err)
+			}
+			c.JSON(aerr.Status, gin.H{"detail": aerr.Detail})
 			c.Abort()
 			return
 		}
 
-		if !strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
-			c.JSON(http.StatusUnauthorized, gin.H{"detail": "Invalid authorization header format."})
-			c.Abort()
-			return
+		if id.APIKey != nil {
+			c.Set(apiKeyContextKey, *id.APIKey)
+			c.Set("api_key_id", id.APIKey.ID)
+		} else {
+			c.Set("email", id.Email)
+			c.Set("issuer", id.Issuer)
+			c.Set("roles", id.Roles)
 		}
+		c.Next()
+	}
+}
 
-		tokenString := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
-		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"detail": "Invalid token."})
-			c.Abort()
-			return
+// Authenticate checks apiKey when it is set, else the Authorization header value.
+func (a *Authenticator) Authenticate(ctx context.Context, authHeader, apiKey string) (Identity, *AuthError) {
+	if apiKey != "" {
+		k, aerr := a.authenticateAPIKey(ctx, apiKey)
+		if aerr != nil {
+			return Identity{}, aerr
 		}
+		return Identity{APIKey: &k}, nil
+	}
 
-		// Parse and validate token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if token.Method != jwt.SigningMethodHS256 {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return jwtSecret, nil
-		})
-
-		if err != nil {
-			if errors.Is(err, jwt.ErrTokenExpired) {
-				c.JSON(http.StatusUnauthorized, gin.H{"detail": "Token has expired."})
-			} else {
-				c.JSON(http.StatusUnauthorized, gin.H{"detail": fmt.Sprintf("Invalid token: %v", err)})
+	if authHeader == "" {
+		return Identity{}, unauthorized("Authorization token is missing")
+	}
+	if !strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
+		return Identity{}, unauthorized("Invalid authorization header format.")
+	}
+	tokenString := strings.TrimSpace(authHeader[len("bearer "):])
+	if tokenString == "" {
+		return Identity{}, unauthorized("Invalid token.")
+	}
+
+	// Parse and validate token
+	token, err := a.parser.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.Alg() {
+		case jwt.SigningMethodHS256.Alg():
+			return a.jwtSecret, nil
+		case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg():
+			if !a.AcceptsIssuerKeys() {
+				return nil, errors.New("asymmetric tokens are not accepted")
 			}
-			c.Abort()
-			return
+		default:
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"detail": "Invalid or expired token"})
-			c.Abort()
-			return
+		// only allow-listed issuers are ever fetched
+		iss, _ := token.Claims.GetIssuer()
+		if !slices.Contains(a.issuers, iss) {
+			return nil, fmt.Errorf("issuer %q is not allowed", iss)
 		}
-
-		// Expiration check
-		if exp, ok := claims["exp"].(float64); ok {
-			if int64(exp) < time.Now().Unix() {
-				c.JSON(http.StatusUnauthorized, gin.H{"detail": "Token has expired."})
-				c.Abort()
-				return
-			}
+		kid, _ := token.Header["kid"].(string)
+		return a.keys.Key(ctx, iss, kid)
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return Identity{}, unauthorized("Token has expired.")
 		}
+		return Identity{}, unauthorized(fmt.Sprintf("Invalid token: %v", err))
+	}
 
-		// Email check
-		email := fmt.Sprintf("%v", claims["email"])
-		if email == "" || email == "<nil>" {
-			c.JSON(http.StatusUnauthorized, gin.H{"detail": "Invalid token: missing email."})
-			c.Abort()
-			return
-		}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return Identity{}, unauthorized("Invalid or expired token")
+	}
 
-		// Issuer check
-		issuer := fmt.Sprintf("%v", claims["iss"])
-		if issuer == "" || issuer == "<nil>" {
-			c.JSON(http.StatusUnauthorized, gin.H{"detail": "Invalid token: missing issuer."})
-			c.Abort()
-			return
+	// Audience check
+	if len(a.audiences) > 0 {
+		aud, _ := claims.GetAudience()
+		if !slices.ContainsFunc(aud, func(s string) bool { return slices.Contains(a.audiences, s) }) {
+			return Identity{}, unauthorized("Invalid token: audience not allowed.")
 		}
+	}
 
-		// Attach email & issuer to context
-		c.Set("email", email)
-		c.Set("issuer", issuer)
+	// Email check
+	email := fmt.Sprintf("%v", claims["email"])
+	if email == "" || email == "<nil>" {
+		return Identity{}, unauthorized("Invalid token: missing email.")
+	}
 
-		c.Next()
+	// an IdP may sign tokens for sign-ups that never proved their email,
+	// and the allow-list and the user roles are keyed on it
+	if token.Method.Alg() != jwt.SigningMethodHS256.Alg() && !emailVerified(claims["email_verified"]) {
+		return Identity{}, unauthorized("Invalid token: email is not verified.")
+	}
+
+	// Issuer check
+	issuer := fmt.Sprintf("%v", claims["iss"])
+	if issuer == "" || issuer == "<nil>" {
+		return Identity{}, unauthorized("Invalid token: missing issuer.")
+	}
+
+	if len(a.issuers) > 0 && !slices.Contains(a.issuers, issuer) {
+		return Identity{}, &AuthError{Status: http.StatusForbidden, Detail: "Token issuer is not allowed."}
 	}
+	if len(a.emails) > 0 && !slices.Contains(a.emails, strings.ToLower(email)) {
+		return Identity{}, &AuthError{Status: http.StatusForbidden, Detail: "Email is not allowed."}
+	}
+
+	return Identity{Email: email, Issuer: issuer, Roles: claimRoles(claims[a.rolesClaim])}, nil
+}
+
+// emailVerified reads the email_verified claim, a boolean or, from some
+// IdPs, the string "true".
+func emailVerified(v any) bool {
+	switch t := v.(type) {
+	case bool:
+		return t
+	case string:
+		return t == "true"
+	}
+	return false
+}
+
+func unauthorized(detail string) *AuthError {
+	return &AuthError{Status: http.StatusUnauthorized, Detail: detail}
 }
@@ -0,0 +1,324 @@
+package middlewares
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang-restapi/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testIssuer is an OIDC issuer serving its discovery document and JWKS.
+type testIssuer struct {
+	*httptest.Server
+	discoveries atomic.Int32
+	fetches     atomic.Int32
+
+	mu     sync.Mutex
+	issuer string // named in the discovery document, the server URL by default
+	keys   map[string]crypto.Signer
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	iss := &testIssuer{keys: map[string]crypto.Signer{}}
+	iss.Server = httptest.NewServer(http.HandlerFunc(iss.serve))
+	t.Cleanup(iss.Close)
+	iss.issuer = iss.URL
+	return iss
+}
+
+func (iss *testIssuer) serve(w http.ResponseWriter, r *http.Request) {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	switch r.URL.Path {
+	case "/.well-known/openid-configuration":
+		iss.discoveries.Add(1)
+		json.NewEncoder(w).Encode(map[string]string{"issuer": iss.issuer, "jwks_uri": iss.URL + "/jwks"})
+	case "/jwks":
+		iss.fetches.Add(1)
+		var set struct {
+			Keys []jwk `json:"keys"`
+		}
+		for kid, k := range iss.keys {
+			set.Keys = append(set.Keys, publicJWK(kid, k.Public()))
+		}
+		json.NewEncoder(w).Encode(set)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// rotate replaces the published keys.
+func (iss *testIssuer) rotate(keys map[string]crypto.Signer) {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	iss.keys = keys
+}
+
+func publicJWK(kid string, pub crypto.PublicKey) jwk {
+	enc := base64.RawURLEncoding.EncodeToString
+	switch p := pub.(type) {
+	case *rsa.PublicKey:
+		return jwk{Kty: "RSA", Kid: kid, Use: "sig", N: enc(p.N.Bytes()), E: enc(big.NewInt(int64(p.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		x, y := make([]byte, 32), make([]byte, 32)
+		return jwk{Kty: "EC", Kid: kid, Crv: "P-256", X: enc(p.X.FillBytes(x)), Y: enc(p.Y.FillBytes(y))}
+	}
+	panic("unsupported key")
+}
+
+func rsaKey(t *testing.T) crypto.Signer {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func ecKey(t *testing.T) crypto.Signer {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+// sign returns a bearer Authorization header for claims, signed by key.
+func sign(t *testing.T, kid string, key crypto.Signer, claims jwt.MapClaims) string {
+	t.Helper()
+	method := jwt.SigningMethod(jwt.SigningMethodRS256)
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		method = jwt.SigningMethodES256
+	}
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Bearer " + s
+}
+
+func claimsFor(iss *testIssuer, aud string, exp time.Time) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            iss.URL,
+		"aud":            aud,
+		"email":          "analyst@example.com",
+		"email_verified": true,
+		"roles":          []string{"admin"},
+		"exp":            exp.Unix(),
+	}
+}
+
+func newTestAuthenticator(iss *testIssuer, audiences ...string) *Authenticator {
+	keys := NewJWKS(time.Hour)
+	keys.MinRefresh = 0
+	return NewAuthenticator(config.Config{
+		AuthIssuers:    []string{iss.URL},
+		AuthAudiences:  audiences,
+		AuthRolesClaim: "roles",
+		AuthClockSkew:  time.Minute,
+	}, keys)
+}
+
+func TestAuthenticateIssuerKeys(t *testing.T) {
+	iss := newTestIssuer(t)
+	rs, es := rsaKey(t), ecKey(t)
+	iss.rotate(map[string]crypto.Signer{"rs": rs, "es": es})
+	a := newTestAuthenticator(iss, "pow-api")
+	hour := time.Now().Add(time.Hour)
+
+	for _, tt := range []struct {
+		name string
+		kid  string
+		key  crypto.Signer
+	}{
+		{"RS256", "rs", rs},
+		{"ES256", "es", es},
+	} {
+		id, aerr := a.Authenticate(context.Background(), sign(t, tt.kid, tt.key, claimsFor(iss, "pow-api", hour)), "")
+		if aerr != nil {
+			t.Errorf("%s: %v", tt.name, aerr)
+			continue
+		}
+		if id.Email != "analyst@example.com" || id.Issuer != iss.URL || len(id.Roles) != 1 || id.Roles[0] != "admin" {
+			t.Errorf("%s: identity = %+v", tt.name, id)
+		}
+	}
+	if n := iss.discoveries.Load(); n != 1 {
+		t.Errorf("discovery fetched %d times, want 1", n)
+	}
+}
+
+func TestAuthenticateRejects(t *testing.T) {
+	iss := newTestIssuer(t)
+	key := rsaKey(t)
+	iss.rotate(map[string]crypto.Signer{"k1": key})
+	a := newTestAuthenticator(iss, "pow-api")
+	hour := time.Now().Add(time.Hour)
+
+	unverified := claimsFor(iss, "pow-api", hour)
+	unverified["email_verified"] = false
+	noVerified := claimsFor(iss, "pow-api", hour)
+	delete(noVerified, "email_verified")
+
+	other := newTestIssuer(t)
+	other.rotate(map[string]crypto.Signer{"k1": key})
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+		detail string
+	}{
+		{"wrong audience", sign(t, "k1", key, claimsFor(iss, "other-api", hour)), 401, "audience not allowed"},
+		{"no audience", sign(t, "k1", key, claimsFor(iss, "", hour)), 401, "audience not allowed"},
+		{"unknown kid", sign(t, "k2", key, claimsFor(iss, "pow-api", hour)), 401, "no matching signing key"},
+		{"wrong key", sign(t, "k1", rsaKey(t), claimsFor(iss, "pow-api", hour)), 401, "Invalid token"},
+		{"issuer not allowed", sign(t, "k1", key, claimsFor(other, "pow-api", hour)), 401, "not allowed"},
+		{"email not verified", sign(t, "k1", key, unverified), 401, "email is not verified"},
+		{"email_verified missing", sign(t, "k1", key, noVerified), 401, "email is not verified"},
+		{"expired beyond the leeway", sign(t, "k1", key, claimsFor(iss, "pow-api", time.Now().Add(-2*time.Minute))), 401, "Token has expired."},
+	}
+	for _, tt := range tests {
+		_, aerr := a.Authenticate(context.Background(), tt.header, "")
+		if aerr == nil {
+			t.Errorf("%s: accepted", tt.name)
+			continue
+		}
+		if aerr.Status != tt.status || !strings.Contains(aerr.Detail, tt.detail) {
+			t.Errorf("%s: %d %q, want %d containing %q", tt.name, aerr.Status, aerr.Detail, tt.status, tt.detail)
+		}
+	}
+	if n := other.discoveries.Load(); n != 0 {
+		t.Errorf("an issuer outside the allow-list was fetched %d times", n)
+	}
+}
+
+func TestAuthenticateClockSkew(t *testing.T) {
+	iss := newTestIssuer(t)
+	key := ecKey(t)
+	iss.rotate(map[string]crypto.Signer{"k1": key})
+	a := newTestAuthenticator(iss, "pow-api")
+
+	// expired 30s ago, within the 1m AUTH_CLOCK_SKEW
+	header := sign(t, "k1", key, claimsFor(iss, "pow-api", time.Now().Add(-30*time.Second)))
+	if _, aerr := a.Authenticate(context.Background(), header, ""); aerr != nil {
+		t.Fatalf("token expired within the leeway: %v", aerr)
+	}
+}
+
+func TestAuthenticateKeyRotation(t *testing.T) {
+	iss := newTestIssuer(t)
+	old, next := rsaKey(t), ecKey(t)
+	iss.rotate(map[string]crypto.Signer{"2025-09": old})
+	a := newTestAuthenticator(iss, "pow-api")
+	hour := time.Now().Add(time.Hour)
+
+	if _, aerr := a.Authenticate(context.Background(), sign(t, "2025-09", old, claimsFor(iss, "pow-api", hour)), ""); aerr != nil {
+		t.Fatalf("before rotation: %v", aerr)
+	}
+
+	iss.rotate(map[string]crypto.Signer{"2025-10": next})
+	if _, aerr := a.Authenticate(context.Background(), sign(t, "2025-10", next, claimsFor(iss, "pow-api", hour)), ""); aerr != nil {
+		t.Fatalf("new kid after rotation: %v", aerr)
+	}
+	if n := iss.fetches.Load(); n != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", n)
+	}
+	if n := iss.discoveries.Load(); n != 1 {
+		t.Errorf("discovery fetched %d times, want 1", n)
+	}
+	if _, aerr := a.Authenticate(context.Background(), sign(t, "2025-09", old, claimsFor(iss, "pow-api", hour)), ""); aerr == nil {
+		t.Error("retired kid still accepted")
+	}
+}
+
+func TestAuthenticateRequiresAudiences(t *testing.T) {
+	iss := newTestIssuer(t)
+	key := rsaKey(t)
+	iss.rotate(map[string]crypto.Signer{"k1": key})
+	a := newTestAuthenticator(iss)
+
+	if a.AcceptsIssuerKeys() {
+		t.Error("issuer keys accepted without AUTH_AUDIENCES")
+	}
+	header := sign(t, "k1", key, claimsFor(iss, "any-client", time.Now().Add(time.Hour)))
+	if _, aerr := a.Authenticate(context.Background(), header, ""); aerr == nil {
+		t.Fatal("RS256 token accepted without AUTH_AUDIENCES")
+	}
+	if n := iss.discoveries.Load(); n != 0 {
+		t.Errorf("issuer fetched %d times", n)
+	}
+}
+
+func TestJWKSDiscoveryIssuerMismatch(t *testing.T) {
+	iss := newTestIssuer(t)
+	iss.rotate(map[string]crypto.Signer{"k1": rsaKey(t)})
+	iss.issuer = "https://accounts.example.com"
+
+	_, err := NewJWKS(time.Hour).Key(context.Background(), iss.URL, "k1")
+	if err == nil || !strings.Contains(err.Error(), "names issuer") {
+		t.Fatalf("Key = %v, want an issuer mismatch error", err)
+	}
+	if n := iss.fetches.Load(); n != 0 {
+		t.Errorf("JWKS of a mismatched issuer fetched %d times", n)
+	}
+}
+
+func TestJWKSConcurrentFetch(t *testing.T) {
+	iss := newTestIssuer(t)
+	iss.rotate(map[string]crypto.Signer{"k1": ecKey(t)})
+	keys := NewJWKS(time.Hour)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := keys.Key(context.Background(), iss.URL, "k1"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := iss.fetches.Load(); n != 1 {
+		t.Errorf("JWKS fetched %d times by concurrent callers, want 1", n)
+	}
+}
+
+func TestAuthenticateHS256(t *testing.T) {
+	a := NewAuthenticator(config.Config{JWTSecret: "secret", AuthClockSkew: time.Minute}, nil)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "pow", "email": "analyst@example.com", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, aerr := a.Authenticate(context.Background(), "Bearer "+token, ""); aerr != nil {
+		t.Fatal(aerr)
+	}
+	if _, aerr := a.Authenticate(context.Background(), "", ""); aerr == nil || aerr.Status != http.StatusUnauthorized {
+		t.Fatalf("missing token: %v", aerr)
+	}
+}
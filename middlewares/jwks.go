@@ -0,0 +1,281 @@
+package middlewares
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownKey is returned when an issuer publishes no key with the token's kid.
+var ErrUnknownKey = errors.New("no matching signing key")
+
+// JWKS caches the signing keys of OIDC issuers. Keys are found through
+// <issuer>/.well-known/openid-configuration and refetched after
+// RefreshInterval, or sooner when a token names a kid we have not seen
+// (the issuer rotated its keys), at most once per MinRefresh. Fetches run
+// without holding the cache lock, one at a time per issuer.
+type JWKS struct {
+	Client          *http.Client
+	RefreshInterval time.Duration
+	MinRefresh      time.Duration
+
+	mu      sync.Mutex // guards issuers
+	issuers map[string]*issuerKeys
+}
+
+type issuerKeys struct {
+	fetch sync.Mutex // held by the caller refreshing the keys
+
+	mu      sync.Mutex // guards the fields below
+	jwksURI string
+	keys    map[string]crypto.PublicKey // by kid
+	fetched time.Time
+	tried   time.Time
+}
+
+// NewJWKS returns an empty key cache refreshing every refresh (1h if zero).
+func NewJWKS(refresh time.Duration) *JWKS {
+	if refresh <= 0 {
+		refresh = time.Hour
+	}
+	return &JWKS{
+		Client:          &http.Client{Timeout: 10 * time.Second},
+		RefreshInterval: refresh,
+		MinRefresh:      time.Minute,
+		issuers:         map[string]*issuerKeys{},
+	}
+}
+
+// Key returns the issuer's public key for kid. An empty kid matches when
+// the issuer publishes a single key. Callers must check the issuer against
+// the allow-list first: the issuer URL is fetched.
+func (j *JWKS) Key(ctx context.Context, issuer, kid string) (crypto.PublicKey, error) {
+	j.mu.Lock()
+	ik := j.issuers[issuer]
+	if ik == nil {
+		ik = &issuerKeys{}
+		j.issuers[issuer] = ik
+	}
+	j.mu.Unlock()
+
+	key, found, due := ik.get(kid, j.RefreshInterval, j.MinRefresh)
+	if found && !due {
+		return key, nil
+	}
+	if found {
+		// stale keys still verify while another caller refreshes them
+		if !ik.fetch.TryLock() {
+			return key, nil
+		}
+	} else {
+		// wait for a fetch in flight, which may bring the kid
+		ik.fetch.Lock()
+	}
+	defer ik.fetch.Unlock()
+
+	// the keys may have been refreshed while we waited for the lock
+	if key, found, due = ik.get(kid, j.RefreshInterval, j.MinRefresh); due {
+		ik.mu.Lock()
+		ik.tried = time.Now()
+		jwksURI := ik.jwksURI
+		ik.mu.Unlock()
+
+		keys, jwksURI, err := j.refresh(ctx, issuer, jwksURI)
+
+		ik.mu.Lock()
+		if err != nil {
+			// keep serving the previous keys while the issuer is unreachable
+			if ik.keys == nil {
+				ik.mu.Unlock()
+				return nil, err
+			}
+			slog.WarnContext(ctx, "jwks refresh failed, serving the previous keys",
+				slog.String("issuer", issuer), slog.Any("error", err))
+		} else {
+			ik.jwksURI, ik.keys, ik.fetched = jwksURI, keys, time.Now()
+		}
+		ik.mu.Unlock()
+		key, found, _ = ik.get(kid, j.RefreshInterval, j.MinRefresh)
+	}
+	return keyResult(issuer, kid, key, found)
+}
+
+// get looks kid up and reports whether the keys are due for a refresh:
+// stale or missing kid, and not tried within minRefresh.
+func (ik *issuerKeys) get(kid string, refresh, minRefresh time.Duration) (key crypto.PublicKey, found, due bool) {
+	ik.mu.Lock()
+	defer ik.mu.Unlock()
+	now := time.Now()
+	key, found = ik.lookup(kid)
+	stale := now.Sub(ik.fetched) > refresh
+	return key, found, (stale || !found) && now.Sub(ik.tried) >= minRefresh
+}
+
+func keyResult(issuer, kid string, key crypto.PublicKey, found bool) (crypto.PublicKey, error) {
+	if !found {
+		return nil, fmt.Errorf("%w: issuer %s, kid %q", ErrUnknownKey, issuer, kid)
+	}
+	return key, nil
+}
+
+func (ik *issuerKeys) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(ik.keys) == 1 {
+		for _, k := range ik.keys {
+			return k, true
+		}
+	}
+	k, ok := ik.keys[kid]
+	return k, ok
+}
+
+// refresh discovers the jwks_uri (unless known) and loads the key set.
+func (j *JWKS) refresh(ctx context.Context, issuer, jwksURI string) (map[string]crypto.PublicKey, string, error) {
+	if jwksURI == "" {
+		var doc struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := j.getJSON(ctx, discoveryURL(issuer), &doc); err != nil {
+			return nil, "", fmt.Errorf("jwks: discovery: %w", err)
+		}
+		// OpenID Connect Discovery 1.0, 4.3: the document must name the
+		// issuer it was fetched for
+		if doc.Issuer != issuerURL(issuer) {
+			return nil, "", fmt.Errorf("jwks: discovery of %s names issuer %q", issuer, doc.Issuer)
+		}
+		if doc.JWKSURI == "" {
+			return nil, "", fmt.Errorf("jwks: discovery of %s has no jwks_uri", issuer)
+		}
+		jwksURI = doc.JWKSURI
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := j.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, "", fmt.Errorf("jwks: keys: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			slog.WarnContext(ctx, "jwks key skipped", slog.String("issuer", issuer),
+				slog.String("kid", k.Kid), slog.Any("error", err))
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	if len(keys) == 0 {
+		return nil, "", fmt.Errorf("jwks: %s publishes no usable keys", jwksURI)
+	}
+	return keys, jwksURI, nil
+}
+
+func (j *JWKS) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := j.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// issuerURL accepts issuers with or without a scheme, since Google issues
+// tokens with iss "accounts.google.com".
+func issuerURL(issuer string) string {
+	if !strings.HasPrefix(issuer, "https://") && !strings.HasPrefix(issuer, "http://") {
+		return "https://" + issuer
+	}
+	return issuer
+}
+
+func discoveryURL(issuer string) string {
+	return strings.TrimSuffix(issuerURL(issuer), "/") + "/.well-known/openid-configuration"
+}
+
+// jwk is one entry of a JSON Web Key Set (RFC 7517); only RSA and EC
+// public keys are supported.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("invalid base64url value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
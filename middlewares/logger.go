@@ -1,19 +1,96 @@
 package middlewares
 
 import (
-	"log"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-restapi/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
-// ErrorLogger prints out any errors that handlers push to the Gin Context.
+// RequestLogger gives every request an ID, taken from X-Request-ID or
+// generated, puts it into the request context and the response, and logs
+// one JSON line per request.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		id := c.GetHeader(utils.RequestIDHeader)
+		if !utils.ValidRequestID(id) {
+			id = utils.NewRequestID()
+		}
+		c.Set("request_id", id)
+		c.Header(utils.RequestIDHeader, id)
+		c.Request = c.Request.WithContext(utils.WithRequestID(c.Request.Context(), id))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		slog.LogAttrs(c.Request.Context(), level, "request",
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("client", clientIdentity(c)),
+			slog.Int("bytes", c.Writer.Size()),
+		)
+	}
+}
+
+// ErrorLogger logs the errors handlers push to the Gin Context, with the
+// repository query that failed.
 func ErrorLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next() // execute handler
 
 		for _, ginErr := range c.Errors {
-			// You can inspect ginErr.Type, .Meta, etc.
-			log.Printf("[GIN ERROR] %v\n", ginErr.Err)
+			attrs := []any{slog.String("route", c.FullPath()), slog.Any("error", ginErr.Err)}
+			if q := queryName(ginErr.Err); q != "" {
+				attrs = append(attrs, slog.String("query", q))
+			}
+			slog.ErrorContext(c.Request.Context(), "handler error", attrs...)
+		}
+	}
+}
+
+// clientIdentity is the API key, the JWT email or "anonymous".
+func clientIdentity(c *gin.Context) string {
+	if id := c.GetInt("api_key_id"); id > 0 {
+		return "api_key:" + strconv.Itoa(id)
+	}
+	if email := c.GetString("email"); email != "" {
+		return email
+	}
+	return "anonymous"
+}
+
+// queryName reads the repository function from errors of the form
+// "GetFeeds: query error: ...", which is named after its query.
+func queryName(err error) string {
+	name, rest, ok := strings.Cut(err.Error(), ": ")
+	if !ok || !strings.Contains(rest, "error") || name == "" {
+		return ""
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return ""
 		}
 	}
+	return name
 }
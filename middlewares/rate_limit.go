@@ -0,0 +1,299 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitResult is the state of a bucket after taking tokens from it.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int           // whole tokens left
+	Reset     time.Duration // until the bucket is full again
+	Retry     time.Duration // until the request would be allowed, when denied
+}
+
+// RateLimitStore keeps token buckets of capacity tokens refilled at rate
+// tokens per second. Take removes cost tokens from the bucket of key, or
+// none when it holds fewer. MemoryRateLimitStore limits one process;
+// PostgresRateLimitStore limits every replica together.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, cost, capacity int, rate float64) (RateLimitResult, error)
+}
+
+// RateLimiter limits each client: the API key, else the JWT email, else the
+// IP. An API key with a RateLimit has a bucket of that many tokens a minute
+// instead of Capacity. Before authentication, LimitIP limits every request
+// by IP to IPCapacity a minute, so that failed attempts are limited too.
+type RateLimiter struct {
+	Store      RateLimitStore
+	Capacity   int     // burst size in tokens
+	Rate       float64 // tokens per second
+	IPCapacity int     // requests per minute per IP before authentication, 0 disables
+}
+
+// NewRateLimiter refills capacity tokens per minute.
+func NewRateLimiter(store RateLimitStore, capacity int) *RateLimiter {
+	return &RateLimiter{Store: store, Capacity: capacity, Rate: float64(capacity) / 60}
+}
+
+// NewRateLimitStore returns the store of a RATE_LIMIT_BACKEND: "memory" or "postgres".
+func NewRateLimitStore(backend string) (RateLimitStore, error) {
+	switch backend {
+	case "memory":
+		return NewMemoryRateLimitStore(), nil
+	case "postgres":
+		return NewPostgresRateLimitStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q (memory or postgres)", backend)
+	}
+}
+
+// Take charges the bucket of the caller cost tokens, at most its capacity.
+func (l *RateLimiter) Take(ctx context.Context, id Identity, ip string, cost int) (RateLimitResult, error) {
+	capacity, rate := l.bucket(id)
+	return l.Store.Take(ctx, RateLimitKey(id, ip), min(cost, capacity), capacity, rate)
+}
+
+// bucket returns the capacity and refill rate of the bucket of id.
+func (l *RateLimiter) bucket(id Identity) (int, float64) {
+	if id.APIKey != nil && id.APIKey.RateLimit > 0 {
+		return id.APIKey.RateLimit, float64(id.APIKey.RateLimit) / 60
+	}
+	return l.Capacity, l.Rate
+}
+
+// TakeIP charges ip one request of its IPCapacity bucket.
+func (l *RateLimiter) TakeIP(ctx context.Context, ip string) (RateLimitResult, error) {
+	if l.IPCapacity <= 0 {
+		return RateLimitResult{Allowed: true}, nil
+	}
+	return l.Store.Take(ctx, "preauth:"+ip, 1, l.IPCapacity, float64(l.IPCapacity)/60)
+}
+
+// LimitIP limits requests by client IP. It must run before Authentication.
+// On a nil limiter it does nothing.
+func (l *RateLimiter) LimitIP() gin.HandlerFunc {
+	if l == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return func(c *gin.Context) {
+		res, err := l.TakeIP(c.Request.Context(), c.ClientIP())
+		if err != nil {
+			c.Error(err)
+			c.Next()
+			return
+		}
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(res.Retry)))
+			c.JSON(http.StatusTooManyRequests, gin.H{"detail": "Rate limit exceeded."})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RouteCosts are the rate limit tokens of the expensive routes, also charged
+// for the AnalyticsService methods mirroring them; others cost 1.
+var RouteCosts = map[string]int{
+	"/pow/feeds":                                    2,
+	"/pow/google_news":                              3,
+	"/pow/most_common_words":                        5,
+	"/pow/get_sentiment_grouped":                    3,
+	"/pow/top_feeds":                                2,
+	"/pow/bias_detection":                           5,
+	"/pow/correlation_between_sources_avg_compound": 5,
+	"/pow/word_co_occurences":                       8,
+	"/pow/phrase_frequency_trends":                  10,
+	"/pow/overall_statistics":                       3,
+	"/pow/entities":                                 5,
+	"/pow/entity_timeline":                          3,
+}
+
+// RouteCost returns the tokens of route in RouteCosts, 1 if not listed.
+func RouteCost(route string) int {
+	if cost, ok := RouteCosts[route]; ok {
+		return cost
+	}
+	return 1
+}
+
+// Limit charges each request costs[c.FullPath()] tokens, 1 for routes not
+// listed, and sets the RateLimit-* headers. It must run after Authentication.
+// On a nil limiter it does nothing.
+func (l *RateLimiter) Limit(costs map[string]int) gin.HandlerFunc {
+	if l == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return func(c *gin.Context) {
+		cost, ok := costs[c.FullPath()]
+		if !ok {
+			cost = 1
+		}
+
+		id := contextIdentity(c)
+		capacity, _ := l.bucket(id)
+		res, err := l.Take(c.Request.Context(), id, c.ClientIP(), cost)
+		if err != nil {
+			// an unreachable store should not take the API down
+			c.Error(err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", strconv.Itoa(capacity)+";w=60")
+		c.Header("RateLimit-Limit", strconv.Itoa(capacity))
+		c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(res.Retry)))
+			c.JSON(http.StatusTooManyRequests, gin.H{"detail": "Rate limit exceeded."})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// contextIdentity is the caller put into the context by Authentication.
+func contextIdentity(c *gin.Context) Identity {
+	id := Identity{Email: c.GetString("email")}
+	if v, ok := c.Get(apiKeyContextKey); ok {
+		if k, ok := v.(models.APIKey); ok {
+			id.APIKey = &k
+		}
+	}
+	return id
+}
+
+// RateLimitKey is the bucket of a caller: the API key, else the JWT email,
+// else the IP.
+func RateLimitKey(id Identity, ip string) string {
+	if id.APIKey != nil {
+		return "key:" + strconv.Itoa(id.APIKey.ID)
+	}
+	if id.Email != "" {
+		return "user:" + id.Email
+	}
+	return "ip:" + ip
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// bucketResult computes a RateLimitResult from the tokens of a bucket
+// after (allowed) or before (denied) taking cost. Shared by the stores.
+func bucketResult(allowed bool, tokens float64, cost, capacity int, rate float64) RateLimitResult {
+	res := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: max(0, int(math.Floor(tokens))),
+		Reset:     refillTime(float64(capacity)-tokens, rate),
+	}
+	if !allowed {
+		res.Retry = refillTime(float64(cost)-tokens, rate)
+	}
+	return res
+}
+
+func refillTime(tokens, rate float64) time.Duration {
+	if tokens <= 0 || rate <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / rate * float64(time.Second))
+}
+
+// MemoryRateLimitStore keeps buckets in this process.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: map[string]*bucket{}, swept: time.Now()}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, cost, capacity int, rate float64) (RateLimitResult, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.buckets[key]
+	if b == nil {
+		b = &bucket{tokens: float64(capacity), updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(capacity), b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	allowed := b.tokens >= float64(cost)
+	if allowed {
+		b.tokens -= float64(cost)
+	}
+	res := bucketResult(allowed, b.tokens, cost, capacity, rate)
+
+	// drop buckets that have refilled, they equal a new one
+	if now.Sub(s.swept) > time.Minute {
+		s.swept = now
+		for k, old := range s.buckets {
+			if old.tokens+now.Sub(old.updated).Seconds()*rate >= float64(capacity) {
+				delete(s.buckets, k)
+			}
+		}
+	}
+	return res, nil
+}
+
+// PostgresRateLimitStore keeps buckets in the rate_limit_buckets table,
+// shared by every replica.
+type PostgresRateLimitStore struct {
+	mu     sync.Mutex
+	purged time.Time
+}
+
+func NewPostgresRateLimitStore() *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{purged: time.Now()}
+}
+
+func (s *PostgresRateLimitStore) Take(ctx context.Context, key string, cost, capacity int, rate float64) (RateLimitResult, error) {
+	allowed, tokens, err := repositories.TakeRateLimitTokens(ctx, key, cost, capacity, rate)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	s.purgeIdle()
+	return bucketResult(allowed, tokens, cost, capacity, rate), nil
+}
+
+// purgeIdle deletes idle buckets, at most hourly, in the background.
+func (s *PostgresRateLimitStore) purgeIdle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.purged) < time.Hour {
+		return
+	}
+	s.purged = time.Now()
+	go func() {
+		ctx := context.Background()
+		if _, err := repositories.PurgeRateLimitBuckets(ctx); err != nil {
+			slog.ErrorContext(ctx, "rate limit buckets not purged", slog.Any("error", err))
+		}
+	}()
+}
@@ -0,0 +1,253 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"golang-restapi/config"
+	"golang-restapi/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLimitIPCountsFailedAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := NewRateLimiter(NewMemoryRateLimitStore(), 60)
+	limiter.IPCapacity = 3
+	auth := NewAuthenticator(config.Config{JWTSecret: "secret", AuthClockSkew: time.Minute}, nil)
+
+	r := gin.New()
+	r.GET("/pow/feeds", limiter.LimitIP(), Authentication(auth), limiter.Limit(nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	get := func(ip string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/pow/feeds", nil)
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := range 3 {
+		if w := get("192.0.2.1"); w.Code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: %d, want 401", i+1, w.Code)
+		}
+	}
+	w := get("192.0.2.1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("attempt 4: %d, want 429", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("no Retry-After header")
+	}
+	if w := get("192.0.2.2"); w.Code != http.StatusUnauthorized {
+		t.Errorf("another IP: %d, want 401", w.Code)
+	}
+}
+
+func TestLimitIPIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		proxies []string
+		remote  string
+		limited bool // the 3rd request with a new X-Forwarded-For
+	}{
+		{"no proxy", nil, "192.0.2.1", true},
+		{"untrusted client", []string{"10.0.0.1"}, "192.0.2.1", true},
+		{"trusted proxy", []string{"10.0.0.1"}, "10.0.0.1", false},
+	}
+	for _, tt := range tests {
+		limiter := NewRateLimiter(NewMemoryRateLimitStore(), 60)
+		limiter.IPCapacity = 2
+		r := gin.New()
+		if err := r.SetTrustedProxies(tt.proxies); err != nil {
+			t.Fatal(err)
+		}
+		r.GET("/pow/feeds", limiter.LimitIP(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		var code int
+		for i := range 3 {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/pow/feeds", nil)
+			req.RemoteAddr = tt.remote + ":1234"
+			req.Header.Set("X-Forwarded-For", "203.0.113."+strconv.Itoa(i+1))
+			r.ServeHTTP(w, req)
+			code = w.Code
+		}
+		if limited := code == http.StatusTooManyRequests; limited != tt.limited {
+			t.Errorf("%s: 3rd request %d, want limited %v", tt.name, code, tt.limited)
+		}
+	}
+}
+
+func TestLimitIPDisabled(t *testing.T) {
+	limiter := NewRateLimiter(NewMemoryRateLimitStore(), 1)
+	for range 5 {
+		res, err := limiter.TakeIP(t.Context(), "192.0.2.1")
+		if err != nil || !res.Allowed {
+			t.Fatalf("TakeIP without IPCapacity = %+v, %v", res, err)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	costs := map[string]int{"/pow/heavy": 4, "/pow/huge": 50}
+
+	type step struct {
+		path      string
+		code      int
+		remaining string
+	}
+	tests := []struct {
+		name  string
+		key   *models.APIKey // else a JWT user
+		limit string
+		steps []step
+	}{
+		{"route costs", nil, "10", []step{
+			{"/pow/heavy", 200, "6"},
+			{"/pow/light", 200, "5"},
+			{"/pow/heavy", 200, "1"},
+			{"/pow/heavy", 429, "1"},
+			{"/pow/light", 200, "0"},
+			{"/pow/light", 429, "0"},
+		}},
+		{"cost capped at the capacity", nil, "10", []step{
+			{"/pow/huge", 200, "0"},
+			{"/pow/light", 429, "0"},
+		}},
+		{"API key capacity", &models.APIKey{ID: 1, RateLimit: 5}, "5", []step{
+			{"/pow/heavy", 200, "1"},
+			{"/pow/heavy", 429, "1"},
+			{"/pow/light", 200, "0"},
+		}},
+		{"API key without a limit", &models.APIKey{ID: 2}, "10", []step{
+			{"/pow/heavy", 200, "6"},
+		}},
+	}
+	for _, tt := range tests {
+		limiter := NewRateLimiter(NewMemoryRateLimitStore(), 10)
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			if tt.key != nil {
+				c.Set(apiKeyContextKey, *tt.key)
+			} else {
+				c.Set("email", "analyst@example.com")
+			}
+		}, limiter.Limit(costs))
+		for _, path := range []string{"/pow/heavy", "/pow/huge", "/pow/light"} {
+			r.GET(path, func(c *gin.Context) { c.Status(http.StatusOK) })
+		}
+
+		for i, st := range tt.steps {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, st.path, nil))
+			h := w.Header()
+			if w.Code != st.code || h.Get("RateLimit-Remaining") != st.remaining {
+				t.Errorf("%s: step %d %s = %d remaining %s, want %d remaining %s",
+					tt.name, i+1, st.path, w.Code, h.Get("RateLimit-Remaining"), st.code, st.remaining)
+			}
+			if h.Get("RateLimit-Limit") != tt.limit || h.Get("RateLimit-Policy") != tt.limit+";w=60" {
+				t.Errorf("%s: step %d limit %q policy %q", tt.name, i+1, h.Get("RateLimit-Limit"), h.Get("RateLimit-Policy"))
+			}
+			if h.Get("RateLimit-Reset") == "" {
+				t.Errorf("%s: step %d without RateLimit-Reset", tt.name, i+1)
+			}
+			if retry := h.Get("Retry-After"); (st.code == http.StatusTooManyRequests) != (retry != "") {
+				t.Errorf("%s: step %d Retry-After %q", tt.name, i+1, retry)
+			}
+		}
+	}
+}
+
+func TestLimitNil(t *testing.T) {
+	var limiter *RateLimiter
+	r := gin.New()
+	r.GET("/", limiter.LimitIP(), limiter.Limit(nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("nil limiter: %d %v", w.Code, w.Header())
+	}
+}
+
+func TestBucketResult(t *testing.T) {
+	tests := []struct {
+		allowed bool
+		tokens  float64
+		cost    int
+		want    RateLimitResult
+	}{
+		{true, 10, 0, RateLimitResult{Allowed: true, Remaining: 10}},
+		{true, 7.5, 1, RateLimitResult{Allowed: true, Remaining: 7, Reset: 2500 * time.Millisecond}},
+		{false, 1, 3, RateLimitResult{Remaining: 1, Reset: 9 * time.Second, Retry: 2 * time.Second}},
+		{false, -0.5, 1, RateLimitResult{Remaining: 0, Reset: 10500 * time.Millisecond, Retry: 1500 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		// capacity 10, one token a second
+		if got := bucketResult(tt.allowed, tt.tokens, tt.cost, 10, 1); got != tt.want {
+			t.Errorf("bucketResult(%v, %v, %d) = %+v, want %+v", tt.allowed, tt.tokens, tt.cost, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryRateLimitStoreRefill(t *testing.T) {
+	s := NewMemoryRateLimitStore()
+	ctx := t.Context()
+	take := func(cost int, rate float64) RateLimitResult {
+		t.Helper()
+		res, err := s.Take(ctx, "k", cost, 10, rate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	if res := take(10, 1); !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("full bucket: %+v", res)
+	}
+	if res := take(1, 1); res.Allowed {
+		t.Fatalf("empty bucket allowed: %+v", res)
+	}
+
+	// five seconds later at one token a second
+	s.buckets["k"].updated = time.Now().Add(-5 * time.Second)
+	if res := take(5, 1); !res.Allowed || res.Remaining != 0 {
+		t.Errorf("after 5s: %+v, want 5 tokens refilled", res)
+	}
+
+	// never beyond the capacity
+	s.buckets["k"].updated = time.Now().Add(-time.Hour)
+	if res := take(1, 1); !res.Allowed || res.Remaining != 9 {
+		t.Errorf("after an hour: %+v, want a full bucket", res)
+	}
+}
+
+func TestMemoryRateLimitStoreSweep(t *testing.T) {
+	s := NewMemoryRateLimitStore()
+	ctx := t.Context()
+	for _, key := range []string{"idle", "busy"} {
+		if _, err := s.Take(ctx, key, 10, 10, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.buckets["idle"].updated = time.Now().Add(-time.Minute)
+	s.swept = time.Now().Add(-2 * time.Minute)
+
+	if _, err := s.Take(ctx, "other", 1, 10, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.buckets["idle"]; ok {
+		t.Error("a refilled bucket was kept")
+	}
+	if _, ok := s.buckets["busy"]; !ok {
+		t.Error("an empty bucket was swept")
+	}
+}
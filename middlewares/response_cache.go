@@ -0,0 +1,261 @@
+package middlewares
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultResponseCache is the cache of the analytics routes, set up in main;
+// nil when response caching is disabled.
+var DefaultResponseCache *ResponseCache
+
+// ResponseCacheConfig configures a ResponseCache.
+type ResponseCacheConfig struct {
+	Size      int           // responses kept
+	PastTTL   time.Duration // for ranges ending before today
+	RecentTTL time.Duration // for ranges including today, or without a range
+}
+
+// ResponseCacheStats are the counters of a ResponseCache.
+type ResponseCacheStats struct {
+	Entries     int   `json:"entries"`
+	Hits        int64 `json:"hits"`
+	NotModified int64 `json:"not_modified"`
+	Misses      int64 `json:"misses"`
+	Evictions   int64 `json:"evictions"`
+}
+
+type responseCacheEntry struct {
+	key         string
+	contentType string
+	body        []byte
+	etag        string
+	start, end  string // start_date and end_date of the request, if any
+	expires     time.Time
+}
+
+// ResponseCache keeps successful GET responses, keyed by route and
+// normalised query, and answers If-None-Match with 304.
+type ResponseCache struct {
+	cfg ResponseCacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front = most recently used
+
+	hits, notModified, misses, evictions atomic.Int64
+}
+
+func NewResponseCache(cfg ResponseCacheConfig) *ResponseCache {
+	return &ResponseCache{
+		cfg:     cfg,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Cache serves cached responses and stores the 200 responses of the
+// handlers after it. On a nil cache it does nothing.
+func (rc *ResponseCache) Cache() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rc == nil || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		query := c.Request.URL.Query()
+		key := c.FullPath() + "?" + normalizeQuery(query)
+
+		if e, ok := rc.get(key); ok {
+			rc.hits.Add(1)
+			c.Header("X-Cache", "HIT")
+			rc.write(c, e)
+			c.Abort()
+			return
+		}
+		rc.misses.Add(1)
+
+		orig := c.Writer
+		w := &bufferedWriter{ResponseWriter: orig}
+		c.Writer = w
+		c.Next()
+		c.Writer = orig
+
+		status := w.Status()
+		if status != http.StatusOK || w.body.Len() == 0 {
+			orig.WriteHeader(status)
+			orig.WriteHeaderNow()
+			orig.Write(w.body.Bytes())
+			return
+		}
+
+		e := &responseCacheEntry{
+			key:         key,
+			contentType: orig.Header().Get("Content-Type"),
+			body:        w.body.Bytes(),
+			etag:        etagOf(w.body.Bytes()),
+			start:       query.Get("start_date"),
+			end:         query.Get("end_date"),
+		}
+		e.expires = time.Now().Add(rc.ttl(e.end))
+		rc.put(e)
+
+		c.Header("X-Cache", "MISS")
+		rc.write(c, e)
+	}
+}
+
+// write answers with e, or 304 when the client already has it.
+func (rc *ResponseCache) write(c *gin.Context, e *responseCacheEntry) {
+	c.Header("ETag", e.etag)
+	if etagMatches(c.GetHeader("If-None-Match"), e.etag) {
+		rc.notModified.Add(1)
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+	c.Data(http.StatusOK, e.contentType, e.body)
+}
+
+// ttl is long for a range that ended before today: those feeds no longer change.
+func (rc *ResponseCache) ttl(end string) time.Duration {
+	if end != "" && end < time.Now().Format("2006-01-02") {
+		return rc.cfg.PastTTL
+	}
+	return rc.cfg.RecentTTL
+}
+
+// Invalidate drops the responses whose date range overlaps [from, to]
+// (YYYY-MM-DD), and those without a range. Called when feeds are written.
+func (rc *ResponseCache) Invalidate(from, to string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key, el := range rc.entries {
+		e := el.Value.(*responseCacheEntry)
+		if e.start == "" || e.end == "" || (e.start <= to && e.end >= from) {
+			rc.lru.Remove(el)
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// Purge drops every response. Called when the stopwords or the entity
+// dictionary change. On a nil cache it does nothing.
+func (rc *ResponseCache) Purge() {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[string]*list.Element)
+	rc.lru.Init()
+}
+
+// Stats returns the cache counters.
+func (rc *ResponseCache) Stats() ResponseCacheStats {
+	rc.mu.Lock()
+	entries := rc.lru.Len()
+	rc.mu.Unlock()
+	return ResponseCacheStats{
+		Entries:     entries,
+		Hits:        rc.hits.Load(),
+		NotModified: rc.notModified.Load(),
+		Misses:      rc.misses.Load(),
+		Evictions:   rc.evictions.Load(),
+	}
+}
+
+func (rc *ResponseCache) get(key string) (*responseCacheEntry, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	el, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*responseCacheEntry)
+	if time.Now().After(e.expires) {
+		rc.lru.Remove(el)
+		delete(rc.entries, key)
+		return nil, false
+	}
+	rc.lru.MoveToFront(el)
+	return e, true
+}
+
+func (rc *ResponseCache) put(e *responseCacheEntry) {
+	if rc.cfg.Size <= 0 {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if el, ok := rc.entries[e.key]; ok {
+		el.Value = e
+		rc.lru.MoveToFront(el)
+		return
+	}
+	rc.entries[e.key] = rc.lru.PushFront(e)
+	for rc.lru.Len() > rc.cfg.Size {
+		oldest := rc.lru.Back()
+		rc.lru.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*responseCacheEntry).key)
+		rc.evictions.Add(1)
+	}
+}
+
+// normalizeQuery sorts the parameters and drops empty ones, so that
+// equivalent queries share an entry.
+func normalizeQuery(q url.Values) string {
+	norm := url.Values{}
+	for k, vs := range q {
+		for _, v := range vs {
+			if v = strings.TrimSpace(v); v != "" {
+				norm.Add(k, v)
+			}
+		}
+	}
+	return norm.Encode()
+}
+
+func etagOf(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches implements If-None-Match with weak comparison.
+func etagMatches(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds the body back until the handler is done, so the
+// ETag header can be set before it is written.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
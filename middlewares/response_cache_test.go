@@ -0,0 +1,191 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cachedRouter serves /words behind rc, answering with status and body and
+// counting the handler calls.
+func cachedRouter(rc *ResponseCache, status *int, body string) (*gin.Engine, *int) {
+	gin.SetMode(gin.TestMode)
+	calls := new(int)
+	r := gin.New()
+	handler := func(c *gin.Context) {
+		*calls++
+		c.Data(*status, "application/json; charset=utf-8", []byte(body))
+	}
+	r.GET("/words", rc.Cache(), handler)
+	r.POST("/words", rc.Cache(), handler)
+	return r, calls
+}
+
+func serve(r *gin.Engine, method, target string, header ...string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func testCache(size int) *ResponseCache {
+	return NewResponseCache(ResponseCacheConfig{Size: size, PastTTL: time.Hour, RecentTTL: time.Minute})
+}
+
+func TestResponseCacheHitAndETag(t *testing.T) {
+	rc := testCache(10)
+	status := http.StatusOK
+	r, calls := cachedRouter(rc, &status, `{"words":[]}`)
+
+	first := serve(r, http.MethodGet, "/words?b=2&a=1")
+	if first.Code != http.StatusOK || first.Header().Get("X-Cache") != "MISS" || first.Body.String() != `{"words":[]}` {
+		t.Fatalf("first = %d %q %q", first.Code, first.Header().Get("X-Cache"), first.Body.String())
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag")
+	}
+
+	// same parameters in another order, with an empty one
+	second := serve(r, http.MethodGet, "/words?a=1&c=&b=2")
+	if second.Header().Get("X-Cache") != "HIT" || second.Header().Get("ETag") != etag || second.Body.String() != `{"words":[]}` {
+		t.Errorf("second = %q %q %q", second.Header().Get("X-Cache"), second.Header().Get("ETag"), second.Body.String())
+	}
+	if second.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("cached Content-Type = %q", second.Header().Get("Content-Type"))
+	}
+
+	for _, inm := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		w := serve(r, http.MethodGet, "/words?a=1&b=2", "If-None-Match", inm)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: %d with %d bytes, want an empty 304", inm, w.Code, w.Body.Len())
+		}
+	}
+	if w := serve(r, http.MethodGet, "/words?a=1&b=2", "If-None-Match", `"other"`); w.Code != http.StatusOK {
+		t.Errorf("stale If-None-Match: %d, want 200", w.Code)
+	}
+
+	if *calls != 1 {
+		t.Errorf("handler called %d times, want 1", *calls)
+	}
+	if s := rc.Stats(); s.Entries != 1 || s.Misses != 1 || s.Hits != 6 || s.NotModified != 4 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestResponseCachePassesErrorsThrough(t *testing.T) {
+	rc := testCache(10)
+	status := http.StatusBadRequest
+	r, calls := cachedRouter(rc, &status, `{"error":"bad date"}`)
+
+	for range 2 {
+		w := serve(r, http.MethodGet, "/words?start_date=x")
+		if w.Code != http.StatusBadRequest || w.Body.String() != `{"error":"bad date"}` {
+			t.Errorf("error response = %d %q", w.Code, w.Body.String())
+		}
+		if w.Header().Get("ETag") != "" {
+			t.Error("error response has an ETag")
+		}
+	}
+	if *calls != 2 || rc.Stats().Entries != 0 {
+		t.Errorf("error responses cached: %d calls, %d entries", *calls, rc.Stats().Entries)
+	}
+
+	status = http.StatusOK
+	serve(r, http.MethodPost, "/words")
+	serve(r, http.MethodPost, "/words")
+	if *calls != 4 || rc.Stats().Entries != 0 {
+		t.Errorf("POST cached: %d calls, %d entries", *calls, rc.Stats().Entries)
+	}
+}
+
+func TestResponseCacheTTL(t *testing.T) {
+	rc := testCache(10)
+	today := time.Now().Format("2006-01-02")
+	tests := []struct {
+		end  string
+		want time.Duration
+	}{
+		{"2020-01-31", time.Hour},
+		{today, time.Minute},
+		{"2999-01-01", time.Minute},
+		{"", time.Minute},
+	}
+	for _, tt := range tests {
+		if got := rc.ttl(tt.end); got != tt.want {
+			t.Errorf("ttl(%q) = %s, want %s", tt.end, got, tt.want)
+		}
+	}
+
+	rc = NewResponseCache(ResponseCacheConfig{Size: 10, PastTTL: time.Hour, RecentTTL: time.Nanosecond})
+	status := http.StatusOK
+	r, calls := cachedRouter(rc, &status, `{}`)
+	serve(r, http.MethodGet, "/words")
+	time.Sleep(time.Millisecond)
+	if w := serve(r, http.MethodGet, "/words"); w.Header().Get("X-Cache") != "MISS" || *calls != 2 {
+		t.Errorf("expired entry served: %q, %d calls", w.Header().Get("X-Cache"), *calls)
+	}
+}
+
+func TestResponseCacheInvalidate(t *testing.T) {
+	ranges := map[string][2]string{
+		"september":   {"2025-09-01", "2025-09-30"},
+		"october":     {"2025-10-01", "2025-10-31"},
+		"overlapping": {"2025-09-15", "2025-10-15"},
+		"ends on day": {"2025-09-01", "2025-10-05"},
+		"no range":    {"", ""},
+		"open end":    {"2025-01-01", ""},
+	}
+	rc := testCache(10)
+	for key, r := range ranges {
+		rc.put(&responseCacheEntry{key: key, start: r[0], end: r[1], expires: time.Now().Add(time.Hour)})
+	}
+
+	rc.Invalidate("2025-10-05", "2025-10-06")
+
+	for key, kept := range map[string]bool{
+		"september":   true,
+		"october":     false,
+		"overlapping": false,
+		"ends on day": false,
+		"no range":    false,
+		"open end":    false,
+	} {
+		if _, ok := rc.get(key); ok != kept {
+			t.Errorf("%s kept %v, want %v", key, ok, kept)
+		}
+	}
+}
+
+func TestResponseCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	rc := testCache(2)
+	put := func(key string) {
+		rc.put(&responseCacheEntry{key: key, expires: time.Now().Add(time.Hour)})
+	}
+	put("a")
+	put("b")
+	rc.get("a") // b is now the least recently used
+	put("c")
+
+	for key, kept := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := rc.get(key); ok != kept {
+			t.Errorf("%s kept %v, want %v", key, ok, kept)
+		}
+	}
+	if s := rc.Stats(); s.Entries != 2 || s.Evictions != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+
+	rc.Purge()
+	if rc.Stats().Entries != 0 {
+		t.Error("Purge kept entries")
+	}
+	var none *ResponseCache
+	none.Purge()
+}
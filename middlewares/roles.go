@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole lets through JWT users having role, from the token's roles
+// claim or from the users table. API keys pass with the admin scope when
+// role is admin, otherwise never.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if v, ok := c.Get(apiKeyContextKey); ok {
+			if k, _ := v.(models.APIKey); role == models.RoleAdmin && k.HasScope(models.ScopeAdmin) {
+				c.Next()
+				return
+			}
+			forbidRole(c, role)
+			return
+		}
+
+		if slices.Contains(c.GetStringSlice("roles"), role) {
+			c.Next()
+			return
+		}
+
+		email := c.GetString("email")
+		if email == "" {
+			forbidRole(c, role)
+			return
+		}
+		roles, err := repositories.UserRoles(c.Request.Context(), email)
+		if err != nil {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to load user roles."})
+			c.Abort()
+			return
+		}
+		if !slices.Contains(roles, role) {
+			forbidRole(c, role)
+			return
+		}
+		c.Set("roles", append(c.GetStringSlice("roles"), roles...))
+		c.Next()
+	}
+}
+
+// RequireScopeOrRole lets through API keys having scope and JWT users
+// having role, e.g. a scraper's key that must not get the admin rights.
+func RequireScopeOrRole(scope, role string) gin.HandlerFunc {
+	requireScope, requireRole := RequireScope(scope), RequireRole(role)
+	return func(c *gin.Context) {
+		if _, ok := c.Get(apiKeyContextKey); ok {
+			requireScope(c)
+			return
+		}
+		requireRole(c)
+	}
+}
+
+func forbidRole(c *gin.Context, role string) {
+	c.JSON(http.StatusForbidden, gin.H{"detail": "Requires the " + role + " role."})
+	c.Abort()
+}
+
+// claimRoles reads a roles claim given as a list or a space/comma
+// separated string.
+func claimRoles(v any) []string {
+	var roles []string
+	switch t := v.(type) {
+	case string:
+		roles = strings.FieldsFunc(t, func(r rune) bool { return r == ' ' || r == ',' })
+	case []any:
+		for _, r := range t {
+			if s, ok := r.(string); ok && s != "" {
+				roles = append(roles, s)
+			}
+		}
+	}
+	return roles
+}
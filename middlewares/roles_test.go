@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang-restapi/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireScopeOrRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name string
+		set  func(c *gin.Context)
+		want int
+	}{
+		{"metrics key", func(c *gin.Context) {
+			c.Set(apiKeyContextKey, models.APIKey{Scopes: []string{models.ScopeReadMetrics}})
+		}, http.StatusOK},
+		{"admin key", func(c *gin.Context) {
+			c.Set(apiKeyContextKey, models.APIKey{Scopes: []string{models.ScopeAdmin}})
+		}, http.StatusOK},
+		{"feeds key", func(c *gin.Context) {
+			c.Set(apiKeyContextKey, models.APIKey{Scopes: []string{models.ScopeReadFeeds}})
+		}, http.StatusForbidden},
+		{"admin user", func(c *gin.Context) {
+			c.Set("roles", []string{models.RoleAdmin})
+		}, http.StatusOK},
+		{"user without roles", func(c *gin.Context) {}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/metrics", tt.set, RequireScopeOrRole(models.ScopeReadMetrics, models.RoleAdmin),
+				func(c *gin.Context) { c.Status(http.StatusOK) })
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			if w.Code != tt.want {
+				t.Errorf("%d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+
+	// the metrics scope grants nothing else
+	if (models.APIKey{Scopes: []string{models.ScopeReadMetrics}}).HasScope(models.ScopeAdmin) {
+		t.Error("read:metrics grants admin")
+	}
+}
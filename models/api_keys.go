@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// API key scopes; ScopeAdmin grants every scope.
+const (
+	ScopeReadFeeds     = "read:feeds"
+	ScopeReadAnalytics = "read:analytics"
+	ScopeReadMetrics   = "read:metrics"
+	ScopeAdmin         = "admin"
+)
+
+// APIKey is an issued key without its secret.
+type APIKey struct {
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	Prefix       string     `json:"prefix"` // first characters of the key, to recognise it
+	Scopes       []string   `json:"scopes"`
+	RateLimit    int        `json:"rate_limit"` // rate limit tokens per minute, 0 = RATE_LIMIT_PER_MINUTE
+	RequestCount int64      `json:"request_count"`
+	LastUsed     *time.Time `json:"last_used"`
+	Created      time.Time  `json:"created"`
+	Revoked      *time.Time `json:"revoked"`
+}
+
+// HasScope reports whether the key grants scope.
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyInput is the body of POST /admin/api_keys.
+type APIKeyInput struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit int      `json:"rate_limit"`
+}
+
+// IssuedAPIKey is returned once, when the key is created.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
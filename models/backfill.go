@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Backfill job statuses.
+const (
+	BackfillPending = "pending"
+	BackfillRunning = "running"
+	BackfillPaused  = "paused"
+	BackfillDone    = "done"
+	BackfillFailed  = "failed"
+)
+
+// BackfillJob is a re-scoring job with its progress.
+type BackfillJob struct {
+	ID          int        `json:"id"`
+	ModelID     int        `json:"model_id"`
+	FromDate    string     `json:"from_date"`
+	ToDate      string     `json:"to_date"`
+	BatchSize   int        `json:"batch_size"`
+	Concurrency int        `json:"concurrency"`
+	Status      string     `json:"status"`
+	CursorMonth *string    `json:"cursor_month"` // month being processed
+	CursorID    int64      `json:"cursor_id"`    // last feed id done in that month
+	Processed   int        `json:"processed"`
+	Scored      int        `json:"scored"`
+	Failed      int        `json:"failed"`
+	Error       *string    `json:"error"`
+	Created     time.Time  `json:"created"`
+	Updated     time.Time  `json:"updated"`
+	Started     *time.Time `json:"started"`
+	Finished    *time.Time `json:"finished"`
+}
+
+// BackfillInput is the body of POST /admin/backfill.
+type BackfillInput struct {
+	ModelID     int    `json:"model_id"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	BatchSize   int    `json:"batch_size"`
+	Concurrency int    `json:"concurrency"`
+}
+
+// BackfillFeed is a feed without a score of the job's model.
+type BackfillFeed struct {
+	ID       int
+	Title    string
+	Lang     string
+	FeedDate string
+}
@@ -0,0 +1,45 @@
+package models
+
+// EntityRow is a named entity with its mention statistics.
+type EntityRow struct {
+	Entity       string   `json:"entity"`
+	MentionCount int      `json:"mention_count"`
+	Sources      []string `json:"sources"`
+	AvgCompound  float64  `json:"avg_compound"`
+}
+
+// EntityTimelineRow holds the mentions of an entity in one date group.
+type EntityTimelineRow struct {
+	Date         string  `json:"date"`
+	MentionCount int     `json:"mention_count"`
+	AvgCompound  float64 `json:"avg_compound"`
+}
+
+// Entity is a canonical entity of the alias dictionary.
+type Entity struct {
+	ID      int           `json:"id"`
+	Name    string        `json:"name"`
+	Kind    string        `json:"kind"`
+	Aliases []EntityAlias `json:"aliases"`
+}
+
+// EntityAlias is an alias (exact surface form) or stem (prefix) of an entity.
+type EntityAlias struct {
+	ID       int    `json:"id"`
+	EntityID int    `json:"entity_id"`
+	Alias    string `json:"alias"`
+	IsStem   bool   `json:"is_stem"`
+}
+
+// EntityInput is the request body for creating or updating an entity.
+type EntityInput struct {
+	Name    string             `json:"name" binding:"required"`
+	Kind    string             `json:"kind"`
+	Aliases []EntityAliasInput `json:"aliases"`
+}
+
+// EntityAliasInput is the request body for adding an alias.
+type EntityAliasInput struct {
+	Alias  string `json:"alias" binding:"required"`
+	IsStem bool   `json:"is_stem"`
+}
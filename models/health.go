@@ -0,0 +1,15 @@
+package models
+
+// Readiness is the answer of /readyz: "ok" or "failed" for every check.
+type Readiness struct {
+	Status string            `json:"status"` // "ready" or "unavailable"
+	Checks map[string]string `json:"checks"`
+}
+
+// BuildInfo is the answer of /version.
+type BuildInfo struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"` // built from a dirty tree
+	GoVersion string `json:"go_version"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// FeedSource is a source with the RSS feed the ingestion worker polls.
+type FeedSource struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Lang   string `json:"lang"`
+	RSSURL string `json:"rss_url"`
+}
+
+// NewFeed is a feed to be inserted by the ingestion worker.
+type NewFeed struct {
+	SourceID    int
+	Title       string
+	Link        string
+	Lang        string
+	Words       []string
+	WordsMasked []string
+	Published   time.Time
+	FeedDate    string // YYYY-MM-DD, the partition key
+}
+
+// NewFeedSentiment is a sentiment score of a feed for one model.
+type NewFeedSentiment struct {
+	FeedID            int
+	ModelID           int
+	FeedDate          string
+	SentimentKey      string
+	SentimentValue    float64
+	SentimentCompound float64
+	Sentiments        map[string]float64
+}
+
+// IngestStats summarises one polling round.
+type IngestStats struct {
+	Sources    int `json:"sources"`
+	Fetched    int `json:"fetched"`
+	Inserted   int `json:"inserted"`
+	Duplicates int `json:"duplicates"`
+	Scored     int `json:"scored"`
+	Failed     int `json:"failed"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// MigrationState is one row of `migrate status`.
+type MigrationState struct {
+	Version int        `json:"version"`
+	Name    string     `json:"name"`
+	Applied *time.Time `json:"applied"` // nil while pending
+}
@@ -0,0 +1,17 @@
+package models
+
+// Partition is a monthly partition of feeds or feed_sentiments.
+type Partition struct {
+	Name string `json:"name"`
+	From string `json:"from"` // inclusive, YYYY-MM-DD
+	To   string `json:"to"`   // exclusive, YYYY-MM-DD
+}
+
+// PartitionReport is the coverage of one partitioned table.
+type PartitionReport struct {
+	Table      string      `json:"table"`
+	Partitions []Partition `json:"partitions"`
+	Missing    []string    `json:"missing"` // YYYY_MM months without a partition
+	Created    []string    `json:"created"`
+	Detached   []string    `json:"detached"`
+}
@@ -35,6 +35,7 @@ type FeedSentiment struct {
 type Source struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
+	Lang string `json:"lang"`
 }
 
 // SentimentGroupRow
@@ -101,12 +102,14 @@ type FeedResponse struct {
 
 type GNewsItem struct {
 	Title     string `json:"title"`
+	Link      string `json:"link"`
 	Published string `json:"published"`
 	Source    string `json:"source"`
 }
 
 type GNewsResponse struct {
 	Title          string  `json:"title"`
+	Link           string  `json:"link"`
 	Source         string  `json:"source"`
 	Published      string  `json:"published"`
 	SentimentKey   string  `json:"sentiment_key"`
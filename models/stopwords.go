@@ -0,0 +1,15 @@
+package models
+
+// Stopword is a row of the stopwords table.
+type Stopword struct {
+	Lang string `json:"lang"`
+	Word string `json:"word"`
+	Kind string `json:"kind"` // "word" or "phrase"
+}
+
+// StopwordsInput is the request body for adding stopwords.
+type StopwordsInput struct {
+	Lang  string   `json:"lang"`
+	Kind  string   `json:"kind"`
+	Words []string `json:"words" binding:"required"`
+}
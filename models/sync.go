@@ -0,0 +1,49 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SyncFeed is a feed read from the source database.
+type SyncFeed struct {
+	Title      string
+	Link       string
+	SourceID   int
+	Words      []string
+	Published  sql.NullTime
+	FeedDate   time.Time
+	CategoryID sql.NullInt64
+	Created    sql.NullTime
+	Updated    time.Time
+}
+
+// SyncFeedSentiment is a sentiment read from the source database with the
+// natural key (source_id, link, feed_date) of its feed.
+type SyncFeedSentiment struct {
+	SourceID          int
+	Link              string
+	FeedDate          time.Time // of the feed
+	ModelID           int
+	Sentiments        sql.NullString
+	SentimentKey      sql.NullString
+	SentimentValue    sql.NullFloat64
+	SentimentCompound sql.NullFloat64
+	SentimentDate     time.Time // feed_date of the sentiment row
+	Created           sql.NullTime
+	Updated           time.Time
+}
+
+// SyncCounts are the outcomes of the upserts of one table.
+type SyncCounts struct {
+	Inserted int `json:"inserted"`
+	Updated  int `json:"updated"`
+	Skipped  int `json:"skipped"` // not newer than the target, or without a target feed/source
+}
+
+// SyncStats summarises a sync run.
+type SyncStats struct {
+	Months     int        `json:"months"`
+	Feeds      SyncCounts `json:"feeds"`
+	Sentiments SyncCounts `json:"sentiments"`
+}
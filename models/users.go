@@ -0,0 +1,6 @@
+package models
+
+// Roles of JWT users, from the token or the users table.
+const (
+	RoleAdmin = "admin"
+)
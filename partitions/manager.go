@@ -0,0 +1,234 @@
+// Package partitions keeps the monthly partitions of feeds and
+// feed_sentiments ahead of the data: a missing month makes inserts fail.
+package partitions
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang-restapi/models"
+	"golang-restapi/repositories"
+)
+
+// Tables are the tables partitioned by month on feed_date.
+var Tables = []string{"feeds", "feed_sentiments"}
+
+// Retention modes for partitions older than the retention window.
+const (
+	RetentionNone    = ""
+	RetentionDetach  = "detach"
+	RetentionArchive = "archive"
+)
+
+// Manager creates upcoming partitions and applies the retention policy.
+type Manager struct {
+	Tables          []string
+	MonthsAhead     int           // partitions kept ready after the current month
+	RetentionMonths int           // 0 keeps every partition
+	RetentionMode   string        // RetentionNone, RetentionDetach or RetentionArchive
+	ArchiveSchema   string        // target schema of RetentionArchive
+	Interval        time.Duration // time between maintenance runs
+}
+
+// NewManager returns a Manager for feeds and feed_sentiments.
+func NewManager(monthsAhead, retentionMonths int, retentionMode string, interval time.Duration) (*Manager, error) {
+	switch retentionMode {
+	case RetentionNone, RetentionDetach, RetentionArchive:
+	default:
+		return nil, fmt.Errorf("unknown partition retention mode %q", retentionMode)
+	}
+	return &Manager{
+		Tables:          Tables,
+		MonthsAhead:     monthsAhead,
+		RetentionMonths: retentionMonths,
+		RetentionMode:   retentionMode,
+		ArchiveSchema:   "archive",
+		Interval:        interval,
+	}, nil
+}
+
+// Run maintains the partitions on every Interval until ctx is done. The
+// startup run is left to the caller (Maintain), so it can finish before
+// anything inserts feeds.
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := m.Maintain(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "partition maintenance failed", slog.Any("error", err))
+		}
+	}
+}
+
+// Maintain creates the missing partitions from the current month up to
+// MonthsAhead, detaches or archives expired ones and reports the coverage.
+func (m *Manager) Maintain(ctx context.Context, now time.Time) ([]models.PartitionReport, error) {
+	reports := make([]models.PartitionReport, 0, len(m.Tables))
+	for _, table := range m.Tables {
+		r, err := m.maintainTable(ctx, table, now)
+		if err != nil {
+			return reports, err
+		}
+		for _, name := range r.Created {
+			slog.InfoContext(ctx, "partition created", slog.String("partition", name))
+		}
+		for _, name := range r.Detached {
+			slog.InfoContext(ctx, "partition expired", slog.String("partition", name),
+				slog.String("retention_mode", m.RetentionMode))
+		}
+		if len(r.Missing) > 0 {
+			slog.WarnContext(ctx, "partitions missing", slog.String("table", table),
+				slog.Any("months", r.Missing))
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// Check reports the coverage without changing anything.
+func (m *Manager) Check(ctx context.Context, now time.Time) ([]models.PartitionReport, error) {
+	reports := make([]models.PartitionReport, 0, len(m.Tables))
+	for _, table := range m.Tables {
+		parts, err := repositories.ListPartitions(ctx, table)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, models.PartitionReport{
+			Table:      table,
+			Partitions: parts,
+			Missing:    MissingMonths(parts, m.lastMonth(now)),
+			Created:    []string{},
+			Detached:   []string{},
+		})
+	}
+	return reports, nil
+}
+
+// CheckCurrent fails when a table has no partition for the month of now,
+// which would make every insert of today's feeds fail.
+func (m *Manager) CheckCurrent(ctx context.Context, now time.Time) error {
+	month := monthStart(now).Format("2006_01")
+	for _, table := range m.Tables {
+		parts, err := repositories.ListPartitions(ctx, table)
+		if err != nil {
+			return err
+		}
+		if !coveredMonths(parts)[month] {
+			return fmt.Errorf("%s has no partition for %s", table, month)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) maintainTable(ctx context.Context, table string, now time.Time) (models.PartitionReport, error) {
+	r := models.PartitionReport{Table: table, Created: []string{}, Detached: []string{}}
+
+	parts, err := repositories.ListPartitions(ctx, table)
+	if err != nil {
+		return r, err
+	}
+	have := coveredMonths(parts)
+
+	for month := monthStart(now); !month.After(m.lastMonth(now)); month = month.AddDate(0, 1, 0) {
+		if have[month.Format("2006_01")] {
+			continue
+		}
+		name, err := repositories.CreateMonthlyPartition(ctx, table, month)
+		if err != nil {
+			return r, err
+		}
+		r.Created = append(r.Created, name)
+	}
+
+	for _, p := range m.expired(parts, now) {
+		if m.RetentionMode == RetentionArchive {
+			err = repositories.ArchivePartition(ctx, table, p.Name, m.ArchiveSchema)
+		} else {
+			err = repositories.DetachPartition(ctx, table, p.Name)
+		}
+		if err != nil {
+			return r, err
+		}
+		r.Detached = append(r.Detached, p.Name)
+	}
+
+	if r.Partitions, err = repositories.ListPartitions(ctx, table); err != nil {
+		return r, err
+	}
+	r.Missing = MissingMonths(r.Partitions, m.lastMonth(now))
+	return r, nil
+}
+
+// expired returns the partitions ending before the retention window, the
+// current month and the RetentionMonths before it. A partition reaching
+// into the window is kept whole.
+func (m *Manager) expired(parts []models.Partition, now time.Time) []models.Partition {
+	if m.RetentionMode == RetentionNone || m.RetentionMonths <= 0 {
+		return nil
+	}
+	cutoff := monthStart(now).AddDate(0, -m.RetentionMonths, 0).Format("2006-01-02")
+	var out []models.Partition
+	for _, p := range parts {
+		if p.To <= cutoff {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (m *Manager) lastMonth(now time.Time) time.Time {
+	return monthStart(now).AddDate(0, m.MonthsAhead, 0)
+}
+
+// MissingMonths lists the months (YYYY_MM) between the first partition and
+// last that no partition covers.
+func MissingMonths(parts []models.Partition, last time.Time) []string {
+	missing := []string{}
+	if len(parts) == 0 {
+		return append(missing, last.Format("2006_01"))
+	}
+
+	have := coveredMonths(parts)
+	first := last
+	for _, p := range parts {
+		if t, err := time.Parse("2006-01-02", p.From); err == nil && t.Before(first) {
+			first = monthStart(t)
+		}
+	}
+	for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+		if !have[month.Format("2006_01")] {
+			missing = append(missing, month.Format("2006_01"))
+		}
+	}
+	return missing
+}
+
+// coveredMonths expands partition bounds into the set of months they cover,
+// so partitions spanning more than a month are handled too.
+func coveredMonths(parts []models.Partition) map[string]bool {
+	have := map[string]bool{}
+	for _, p := range parts {
+		from, err1 := time.Parse("2006-01-02", p.From)
+		to, err2 := time.Parse("2006-01-02", p.To)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		for month := monthStart(from); month.Before(to); month = month.AddDate(0, 1, 0) {
+			have[month.Format("2006_01")] = true
+		}
+	}
+	return have
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,118 @@
+package partitions
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"golang-restapi/models"
+)
+
+func part(name, from, to string) models.Partition {
+	return models.Partition{Name: name, From: from, To: to}
+}
+
+func TestCoveredMonths(t *testing.T) {
+	got := coveredMonths([]models.Partition{
+		part("feeds_2025_01", "2025-01-01", "2025-02-01"),
+		part("feeds_2024_q4", "2024-10-01", "2025-01-01"), // a quarter
+		part("feeds_2025_03", "2025-03-15", "2025-04-01"), // starts mid-month
+		part("feeds_broken", "2025-05-01", "tomorrow"),
+	})
+	want := []string{"2024_10", "2024_11", "2024_12", "2025_01", "2025_03"}
+	if keys := slices.Sorted(maps.Keys(got)); !slices.Equal(keys, want) {
+		t.Errorf("coveredMonths = %v, want %v", keys, want)
+	}
+}
+
+func TestMissingMonths(t *testing.T) {
+	oct := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		parts []models.Partition
+		last  time.Time
+		want  []string
+	}{
+		{"no partitions", nil, oct, []string{"2025_10"}},
+		{"complete", []models.Partition{
+			part("feeds_2025_09", "2025-09-01", "2025-10-01"),
+			part("feeds_2025_10", "2025-10-01", "2025-11-01"),
+		}, oct, []string{}},
+		{"gap and missing end", []models.Partition{
+			part("feeds_2025_07", "2025-07-01", "2025-08-01"),
+			part("feeds_2025_09", "2025-09-01", "2025-10-01"),
+		}, oct.AddDate(0, 1, 0), []string{"2025_08", "2025_10", "2025_11"}},
+		{"partition spanning months", []models.Partition{
+			part("feeds_2025_q3", "2025-07-01", "2025-10-01"),
+			part("feeds_2025_10", "2025-10-01", "2025-11-01"),
+		}, oct, []string{}},
+		{"spanning partition ending early", []models.Partition{
+			part("feeds_2025_h1", "2025-01-01", "2025-07-01"),
+		}, oct, []string{"2025_07", "2025_08", "2025_09", "2025_10"}},
+		{"partitions after last", []models.Partition{
+			part("feeds_2025_12", "2025-12-01", "2026-01-01"),
+		}, oct, []string{"2025_10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MissingMonths(tt.parts, tt.last); !slices.Equal(got, tt.want) {
+				t.Errorf("MissingMonths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpired(t *testing.T) {
+	parts := []models.Partition{
+		part("feeds_2024_h2", "2024-07-01", "2025-01-01"),
+		part("feeds_2025_05", "2025-05-01", "2025-06-01"),
+		part("feeds_2025_06", "2025-06-01", "2025-07-01"),
+		part("feeds_2025_q3", "2025-07-01", "2025-10-01"),
+		part("feeds_2025_10", "2025-10-01", "2025-11-01"),
+	}
+	now := time.Date(2025, 10, 16, 12, 0, 0, 0, time.UTC)
+	names := func(ps []models.Partition) []string {
+		out := []string{}
+		for _, p := range ps {
+			out = append(out, p.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		mode   string
+		months int
+		want   []string
+	}{
+		{RetentionDetach, 0, []string{}},
+		{RetentionNone, 4, []string{}},
+		// the window is October and the four months before it
+		{RetentionDetach, 4, []string{"feeds_2024_h2", "feeds_2025_05"}},
+		// June ends where the window starts, on July 1
+		{RetentionArchive, 3, []string{"feeds_2024_h2", "feeds_2025_05", "feeds_2025_06"}},
+		// the quarter reaches into the window, which starts in September
+		{RetentionDetach, 1, []string{"feeds_2024_h2", "feeds_2025_05", "feeds_2025_06"}},
+		{RetentionDetach, 24, []string{}},
+	}
+	for _, tt := range tests {
+		m := &Manager{RetentionMode: tt.mode, RetentionMonths: tt.months}
+		if got := names(m.expired(parts, now)); !slices.Equal(got, tt.want) {
+			t.Errorf("expired(%q, %d months) = %v, want %v", tt.mode, tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestNewManagerRejectsUnknownMode(t *testing.T) {
+	if _, err := NewManager(2, 12, "drop", time.Hour); err == nil {
+		t.Error("NewManager accepted retention mode drop")
+	}
+	m, err := NewManager(2, 12, RetentionArchive, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC)
+	if last := m.lastMonth(now); !last.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("lastMonth = %s, want 2026-02-01", last)
+	}
+}
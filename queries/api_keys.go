@@ -0,0 +1,28 @@
+package queries
+
+const (
+	apiKeyColumns = `
+        id, name, prefix, scopes, rate_limit, request_count, last_used, created, revoked
+    `
+
+	ListAPIKeys = `SELECT` + apiKeyColumns + `FROM api_keys ORDER BY id`
+
+	InsertAPIKey = `
+        INSERT INTO api_keys (name, prefix, key_hash, scopes, rate_limit)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING` + apiKeyColumns
+
+	RevokeAPIKey = `
+        UPDATE api_keys SET revoked = COALESCE(revoked, now())
+        WHERE id = $1
+    `
+
+	// UseAPIKey looks up an active key by hash and counts the request in
+	// the same round trip.
+	UseAPIKey = `
+        UPDATE api_keys
+        SET request_count = request_count + 1,
+            last_used     = now()
+        WHERE key_hash = $1 AND revoked IS NULL
+        RETURNING` + apiKeyColumns
+)
@@ -0,0 +1,60 @@
+package queries
+
+const (
+	backfillJobColumns = `
+        id, model_id, to_char(from_date, 'YYYY-MM-DD'), to_char(to_date, 'YYYY-MM-DD'),
+        batch_size, concurrency, status, to_char(cursor_month, 'YYYY-MM-DD'), cursor_id,
+        processed, scored, failed, error, created, updated, started, finished
+    `
+
+	ListBackfillJobs = `SELECT` + backfillJobColumns + `FROM backfill_jobs ORDER BY id DESC`
+
+	GetBackfillJob = `SELECT` + backfillJobColumns + `FROM backfill_jobs WHERE id = $1`
+
+	// ListInterruptedBackfillJobs returns the jobs that were running when the
+	// process stopped.
+	ListInterruptedBackfillJobs = `SELECT` + backfillJobColumns + `FROM backfill_jobs WHERE status = 'running' ORDER BY id`
+
+	InsertBackfillJob = `
+        INSERT INTO backfill_jobs (model_id, from_date, to_date, batch_size, concurrency)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id
+    `
+
+	SetBackfillStatus = `
+        UPDATE backfill_jobs
+        SET status   = $2,
+            error    = $3,
+            started  = CASE WHEN $2 = 'running' THEN COALESCE(started, now()) ELSE started END,
+            finished = CASE WHEN $2 IN ('done', 'failed') THEN now() END,
+            updated  = now()
+        WHERE id = $1
+    `
+
+	SetBackfillProgress = `
+        UPDATE backfill_jobs
+        SET cursor_month = $2,
+            cursor_id    = $3,
+            processed    = $4,
+            scored       = $5,
+            failed       = $6,
+            updated      = now()
+        WHERE id = $1
+    `
+
+	// BackfillFeeds pages through the feeds of one month that have no score of
+	// model $5 yet, in id order after the cursor $4.
+	BackfillFeeds = `
+        SELECT f.id, f.title, f.lang, to_char(f.feed_date, 'YYYY-MM-DD')
+        FROM feeds f
+        WHERE f.feed_date >= $1 AND f.feed_date < $2
+        AND f.feed_date BETWEEN $3 AND $6
+        AND f.id > $4
+        AND NOT EXISTS (
+            SELECT 1 FROM feed_sentiments fs
+            WHERE fs.feed_id = f.id AND fs.model_id = $5 AND fs.feed_date = f.feed_date
+        )
+        ORDER BY f.id
+        LIMIT $7
+    `
+)
@@ -0,0 +1,72 @@
+package queries
+
+const (
+	// EntityMentions returns the titles (with source and compound score)
+	// entities are extracted from. $3 is an optional source filter, $4 the language.
+	EntityMentions = `
+        SELECT
+            f.title,
+            s.name,
+            f.feed_date,
+            fs.sentiment_compound
+        FROM feeds f
+        JOIN sources s ON s.id = f.source_id
+        LEFT JOIN feed_sentiments fs
+          ON fs.feed_id = f.id AND fs.model_id = 1 AND fs.feed_date BETWEEN $1 AND $2
+        WHERE f.feed_date BETWEEN $1 AND $2
+        AND (COALESCE(array_length($3::int[], 1), 0) = 0 OR f.source_id = ANY($3::int[]))
+        AND f.lang = $4
+    `
+)
+
+const (
+	// ListEntities returns every entity with its aliases (NULLs when it has none).
+	ListEntities = `
+        SELECT e.id, e.name, e.kind, a.id, a.alias, a.is_stem
+        FROM entities e
+        LEFT JOIN entity_aliases a ON a.entity_id = e.id
+        ORDER BY e.name, a.alias
+    `
+
+	GetEntity = `
+        SELECT e.id, e.name, e.kind, a.id, a.alias, a.is_stem
+        FROM entities e
+        LEFT JOIN entity_aliases a ON a.entity_id = e.id
+        WHERE e.id = $1
+        ORDER BY a.alias
+    `
+
+	InsertEntity = `
+        INSERT INTO entities (name, kind)
+        VALUES ($1, $2)
+        RETURNING id
+    `
+
+	UpdateEntity = `
+        UPDATE entities
+        SET name = $2, kind = $3, updated = now()
+        WHERE id = $1
+    `
+
+	DeleteEntity = `
+        DELETE FROM entities
+        WHERE id = $1
+    `
+
+	InsertEntityAlias = `
+        INSERT INTO entity_aliases (entity_id, alias, is_stem)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (entity_id, alias, is_stem) DO UPDATE SET alias = EXCLUDED.alias
+        RETURNING id
+    `
+
+	DeleteEntityAliases = `
+        DELETE FROM entity_aliases
+        WHERE entity_id = $1
+    `
+
+	DeleteEntityAlias = `
+        DELETE FROM entity_aliases
+        WHERE entity_id = $1 AND id = $2
+    `
+)
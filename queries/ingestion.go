@@ -0,0 +1,43 @@
+package queries
+
+const (
+	// ListFeedSources returns the active sources with an RSS feed.
+	ListFeedSources = `
+        SELECT id, name, lang, rss_url
+        FROM sources
+        WHERE active AND COALESCE(rss_url, '') <> ''
+        ORDER BY id
+    `
+
+	MarkSourcePolled = `
+        UPDATE sources
+        SET last_polled = now()
+        WHERE id = $1
+    `
+
+	// InsertFeed skips duplicates on (source_id, link, feed_date);
+	// no row is returned for a duplicate.
+	InsertFeed = `
+        INSERT INTO feeds
+            (title, link, source_id, lang, words, words_masked, published, feed_date,
+             search_vector, created, updated)
+        VALUES
+            ($1, $2, $3, $4, $5, $6, $7, $8,
+             to_tsvector($9::regconfig, $1), now(), now())
+        ON CONFLICT (source_id, link, feed_date) DO NOTHING
+        RETURNING id
+    `
+
+	UpsertFeedSentiment = `
+        INSERT INTO feed_sentiments AS tgt
+            (feed_id, model_id, sentiments, sentiment_key, sentiment_value,
+             sentiment_compound, feed_date, created, updated)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, now(), now())
+        ON CONFLICT (feed_id, model_id, feed_date) DO UPDATE
+        SET sentiments         = EXCLUDED.sentiments,
+            sentiment_key      = EXCLUDED.sentiment_key,
+            sentiment_value    = EXCLUDED.sentiment_value,
+            sentiment_compound = EXCLUDED.sentiment_compound,
+            updated            = now()
+    `
+)
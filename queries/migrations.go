@@ -0,0 +1,21 @@
+package queries
+
+const (
+	CreateSchemaMigrations = `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version  int         PRIMARY KEY,
+            name     text        NOT NULL,
+            applied  timestamptz NOT NULL DEFAULT now()
+        )
+    `
+
+	ListAppliedMigrations = `
+        SELECT version, name, applied
+        FROM schema_migrations
+        ORDER BY version
+    `
+
+	InsertMigration = `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`
+
+	DeleteMigration = `DELETE FROM schema_migrations WHERE version = $1`
+)
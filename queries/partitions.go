@@ -0,0 +1,28 @@
+package queries
+
+const (
+	// ListPartitions returns the partitions of a public table with their bounds,
+	// e.g. FOR VALUES FROM ('2025-01-01') TO ('2025-02-01').
+	ListPartitions = `
+        SELECT c.relname, pg_get_expr(c.relpartbound, c.oid)
+        FROM pg_inherits i
+        JOIN pg_class c     ON c.oid = i.inhrelid
+        JOIN pg_class p     ON p.oid = i.inhparent
+        JOIN pg_namespace n ON n.oid = p.relnamespace
+        WHERE n.nspname = 'public' AND p.relname = $1
+        ORDER BY c.relname
+    `
+
+	// CreateMonthlyPartition is formatted with the partition name, the parent
+	// table and the month bounds (identifiers are quoted by the caller).
+	CreateMonthlyPartition = `
+        CREATE TABLE IF NOT EXISTS public.%s PARTITION OF public.%s
+            FOR VALUES FROM ('%s') TO ('%s')
+    `
+
+	DetachPartition = `ALTER TABLE public.%s DETACH PARTITION public.%s`
+
+	CreateArchiveSchema = `CREATE SCHEMA IF NOT EXISTS %s`
+
+	MovePartitionToSchema = `ALTER TABLE public.%s SET SCHEMA %s`
+)
@@ -5,7 +5,7 @@ const (
         SELECT
             f.id, f.title, f.link, f.source_id, f.words, f.published,
             fs.id, fs.sentiment_key, fs.sentiment_value, fs.sentiment_compound,
-            s.id, s.name
+            s.id, s.name, s.lang
         FROM feeds f
         JOIN feed_sentiments fs ON fs.feed_id = f.id AND fs.model_id = 1
         JOIN sources s ON f.source_id = s.id
@@ -26,11 +26,12 @@ const (
         `
 
 	// GetWordsByDateRange returns only the 'words' column
-	// for all feeds whose feed_date is between two given dates.
+	// for all feeds of a language whose feed_date is between two given dates.
 	GetWordsByDateRange = `
         SELECT words
         FROM feeds
         WHERE feed_date BETWEEN $1 AND $2
+        AND lang = $3
     `
 
 	// GetSentimentGroupedBySource
@@ -97,7 +98,8 @@ const (
         JOIN feed_sentiments fs ON fs.feed_id = f.id AND fs.model_id = 1
         JOIN sources s          ON s.id = f.source_id
         WHERE f.feed_date BETWEEN $2 AND $3                          -- use feed_date for partition pruning
-        AND f.search_vector @@ to_tsquery('public.hun_unaccent', $1 || ':*')
+        AND f.lang = $5
+        AND f.search_vector @@ to_tsquery($6::regconfig, $4)
         GROUP BY s.name
         ORDER BY net_sentiment_score DESC;
     `
@@ -111,26 +113,32 @@ const (
         FROM feeds f
         JOIN feed_sentiments fs ON fs.feed_id = f.id AND fs.model_id = 1 AND fs.feed_date BETWEEN $2 AND $3
         LEFT JOIN sources s ON f.source_id = s.id
-        WHERE f.search_vector @@ to_tsquery('public.hun_unaccent', $1)
+        WHERE f.search_vector @@ to_tsquery($4::regconfig, $1)
             AND f.feed_date BETWEEN $2 AND $3 AND fs.feed_date BETWEEN $2 AND $3
+            AND f.lang = $5
             %s
         GROUP BY s.name, month
         ORDER BY s.name, month
     `
 
+	// WordCoOccurrences: the first %s is the target condition on $1
+	// (WordCoOccurrencesByWord, ByStem or ByEntity), $5 are the regexes of the
+	// target word(s) excluded from the co-words. Merging, HAVING > 1 and the
+	// top 30 cut are done after normalization, in the repository.
 	WordCoOccurrences = `
         WITH target_articles AS (
             SELECT f.id, f.words
             FROM feeds f
-            WHERE $1 = ANY(f.words)
+            WHERE %s
                 AND f.feed_date BETWEEN $2 AND $3
+                AND f.lang = $6
                 %s
         ),
         co_words AS (
             SELECT ta.id AS feed_id, w AS co_word
             FROM target_articles ta,
                 unnest(ta.words) AS w
-            WHERE w <> $1 AND NOT (w = ANY($4))
+            WHERE NOT (w ~* ANY($5::text[])) AND NOT (w = ANY($4))
         ),
         sentiments AS (
             SELECT feed_id,
@@ -149,12 +157,13 @@ const (
             COALESCE(SUM(s.neu_count), 0) AS neutral_count
             FROM co_words cw
             LEFT JOIN sentiments s ON cw.feed_id = s.feed_id
-        GROUP BY cw.co_word
-        HAVING COUNT(*) > 1
-        ORDER BY co_occurrence DESC
-        LIMIT 30;
+        GROUP BY cw.co_word;
     `
 
+	WordCoOccurrencesByWord   = `$1::text = ANY(f.words)`
+	WordCoOccurrencesByStem   = `EXISTS (SELECT 1 FROM unnest(f.words) AS tw WHERE tw ~* ANY($1::text[]))`
+	WordCoOccurrencesByEntity = `f.title ~* ANY($1::text[])`
+
 	phraseExclusion = `
         AND NOT ()
     `
@@ -220,6 +229,7 @@ const (
         FROM feeds f
         JOIN sources s ON f.source_id = s.id
         WHERE f.feed_date BETWEEN $1 AND $2
+        AND f.lang = $8
         AND CASE WHEN $5::boolean THEN f.words_masked IS NOT NULL ELSE f.words IS NOT NULL END
         AND (COALESCE(array_length($7::int[], 1), 0) = 0 OR f.source_id = ANY($7::int[]))
         ),
@@ -238,6 +248,9 @@ const (
             -- token stopwords
             AND lower(b.arr[i])     <> ALL($4::text[])
             AND lower(b.arr[i + 1]) <> ALL($4::text[])
+            -- numbers and dates ("2025.", "15.")
+            AND b.arr[i]     !~ '^[0-9]+\.?$'
+            AND b.arr[i + 1] !~ '^[0-9]+\.?$'
             -- bigram exclusion only when names_excluded = true
             AND (
                 NOT $5::boolean
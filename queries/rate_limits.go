@@ -0,0 +1,21 @@
+package queries
+
+const (
+	// TakeRateLimitTokens refills the bucket $1 (capacity $2, $4 tokens per
+	// second) and takes $3 tokens if it holds enough, in one statement.
+	TakeRateLimitTokens = `
+        INSERT INTO rate_limit_buckets AS b (key, tokens, last_allowed, updated)
+        VALUES ($1, $2::float8 - $3::float8, true, now())
+        ON CONFLICT (key) DO UPDATE
+        SET tokens = CASE
+                WHEN LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated) * $4::float8) >= $3::float8
+                THEN LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated) * $4::float8) - $3::float8
+                ELSE LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated) * $4::float8)
+            END,
+            last_allowed = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated) * $4::float8) >= $3::float8,
+            updated = now()
+        RETURNING tokens, last_allowed
+    `
+
+	PurgeRateLimitBuckets = `DELETE FROM rate_limit_buckets WHERE updated < now() - interval '1 day'`
+)
@@ -0,0 +1,5 @@
+package queries
+
+// NotifyResponseCache tells the API servers listening on the channel ($1)
+// that feeds of a date range ($2, "from,to") changed.
+const NotifyResponseCache = `SELECT pg_notify($1, $2)`
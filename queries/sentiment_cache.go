@@ -0,0 +1,28 @@
+package queries
+
+const (
+	// GetCachedSentiments returns the cached results of the keys $1 younger than $2 seconds.
+	GetCachedSentiments = `
+        SELECT key, sentiment_key, sentiment_value, sentiments
+        FROM sentiment_cache
+        WHERE key = ANY($1::text[])
+        AND created > now() - make_interval(secs => $2)
+    `
+
+	PutCachedSentiment = `
+        INSERT INTO sentiment_cache (key, model_version, sentiment_key, sentiment_value, sentiments, created)
+        VALUES ($1, $2, $3, $4, $5, now())
+        ON CONFLICT (key) DO UPDATE
+        SET sentiment_key   = EXCLUDED.sentiment_key,
+            sentiment_value = EXCLUDED.sentiment_value,
+            sentiments      = EXCLUDED.sentiments,
+            created         = now()
+    `
+
+	// PurgeSentimentCache drops the rows of other model versions and the expired ones.
+	PurgeSentimentCache = `
+        DELETE FROM sentiment_cache
+        WHERE model_version <> $1
+        OR created <= now() - make_interval(secs => $2)
+    `
+)
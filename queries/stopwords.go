@@ -0,0 +1,23 @@
+package queries
+
+const (
+	// ListStopwords: empty $1/$2 means no lang/kind filter.
+	ListStopwords = `
+        SELECT lang, word, kind
+        FROM stopwords
+        WHERE ($1 = '' OR lang = $1)
+        AND ($2 = '' OR kind = $2)
+        ORDER BY lang, kind, word
+    `
+
+	InsertStopword = `
+        INSERT INTO stopwords (lang, word, kind)
+        VALUES ($1, $2, $3)
+        ON CONFLICT DO NOTHING
+    `
+
+	DeleteStopword = `
+        DELETE FROM stopwords
+        WHERE lang = $1 AND word = $2 AND kind = $3
+    `
+)
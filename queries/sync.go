@@ -0,0 +1,131 @@
+package queries
+
+// Queries of the DB-to-DB sync. The Source* queries run on the source
+// database, the others on the target.
+const (
+	GetSyncHighWater = `SELECT high_water FROM sync_state WHERE name = $1`
+
+	SetSyncHighWater = `
+        INSERT INTO sync_state (name, high_water, updated)
+        VALUES ($1, $2, now())
+        ON CONFLICT (name) DO UPDATE
+        SET high_water = EXCLUDED.high_water,
+            updated    = now()
+    `
+
+	// SourceNow is the upper bound of a sync run, taken on the source clock.
+	SourceNow = `SELECT now()`
+
+	// SourceChangedMonths returns the first days of the months with feeds or
+	// sentiments updated after the high-water marks ($1 feeds, $2 sentiments)
+	// and up to $3.
+	SourceChangedMonths = `
+        SELECT DISTINCT date_trunc('month', d)::date AS month
+        FROM (
+            SELECT COALESCE(f.feed_date, f.published::date) AS d
+            FROM feeds f
+            WHERE f.updated > $1 AND f.updated <= $3
+            UNION ALL
+            SELECT COALESCE(s.feed_date, f.feed_date, f.published::date)
+            FROM feed_sentiments s
+            JOIN feeds f ON f.id = s.feed_id
+            WHERE s.updated > $2 AND s.updated <= $3
+        ) changed
+        WHERE d IS NOT NULL
+        ORDER BY month
+    `
+
+	// SourceFeeds returns the feeds of a month updated in ($3, $4], deduplicated
+	// by (source_id, link, feed_date) keeping the latest version.
+	SourceFeeds = `
+        SELECT title, link, source_id, words, published, fd, category_id, created, updated
+        FROM (
+            SELECT
+                f.title, f.link, f.source_id, f.words, f.published,
+                COALESCE(f.feed_date, f.published::date) AS fd,
+                f.category_id, f.created, f.updated,
+                ROW_NUMBER() OVER (
+                    PARTITION BY f.source_id, f.link, COALESCE(f.feed_date, f.published::date)
+                    ORDER BY f.updated DESC NULLS LAST, f.created DESC NULLS LAST, f.id DESC
+                ) AS rn
+            FROM feeds f
+            WHERE COALESCE(f.feed_date, f.published::date) >= $1
+            AND COALESCE(f.feed_date, f.published::date) < $2
+            AND f.updated > $3 AND f.updated <= $4
+        ) src
+        WHERE rn = 1
+    `
+
+	// SourceFeedSentiments returns the sentiments of a month updated in ($3, $4]
+	// with the natural key of their feed, deduplicated like SourceFeeds.
+	SourceFeedSentiments = `
+        SELECT source_id, link, feed_fd, model_id, sentiments, sentiment_key,
+               sentiment_value, sentiment_compound, fd, created, updated
+        FROM (
+            SELECT
+                f.source_id, f.link,
+                COALESCE(f.feed_date, f.published::date) AS feed_fd,
+                s.model_id, s.sentiments::text AS sentiments, s.sentiment_key,
+                s.sentiment_value, s.sentiment_compound,
+                COALESCE(s.feed_date, f.feed_date, f.published::date) AS fd,
+                s.created, s.updated,
+                ROW_NUMBER() OVER (
+                    PARTITION BY f.source_id, f.link, COALESCE(f.feed_date, f.published::date), s.model_id
+                    ORDER BY s.updated DESC NULLS LAST, s.created DESC NULLS LAST, s.id DESC
+                ) AS rn
+            FROM feed_sentiments s
+            JOIN feeds f ON f.id = s.feed_id
+            WHERE COALESCE(s.feed_date, f.feed_date, f.published::date) >= $1
+            AND COALESCE(s.feed_date, f.feed_date, f.published::date) < $2
+            AND s.updated > $3 AND s.updated <= $4
+        ) src
+        WHERE rn = 1
+    `
+
+	TargetSourceLangs = `SELECT id, lang FROM sources`
+
+	TargetCategoryIDs = `SELECT id FROM feed_categories`
+
+	// TargetFeedKeys maps (source_id, link, feed_date) to the target feed id.
+	TargetFeedKeys = `
+        SELECT id, source_id, link, feed_date
+        FROM feeds
+        WHERE feed_date >= $1 AND feed_date < $2
+    `
+
+	// SyncFeed upserts a source feed; rows not newer than the target are left
+	// alone and return nothing. inserted tells an insert from an update.
+	SyncFeed = `
+        INSERT INTO feeds AS t
+            (title, link, source_id, lang, words, words_masked, published, feed_date,
+             category_id, search_vector, created, updated)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, to_tsvector($12::regconfig, $1),
+                COALESCE($10, now()), $11)
+        ON CONFLICT (source_id, link, feed_date) DO UPDATE
+        SET title         = EXCLUDED.title,
+            words         = EXCLUDED.words,
+            words_masked  = EXCLUDED.words_masked,
+            published     = EXCLUDED.published,
+            category_id   = EXCLUDED.category_id,
+            search_vector = EXCLUDED.search_vector,
+            updated       = EXCLUDED.updated
+        WHERE t.updated IS NULL OR t.updated < EXCLUDED.updated
+        RETURNING (xmax = 0) AS inserted
+    `
+
+	SyncFeedSentiment = `
+        INSERT INTO feed_sentiments AS tgt
+            (feed_id, model_id, sentiments, sentiment_key, sentiment_value,
+             sentiment_compound, feed_date, created, updated)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, now()), $9)
+        ON CONFLICT (feed_id, model_id, feed_date) DO UPDATE
+        SET sentiments         = EXCLUDED.sentiments,
+            sentiment_key      = EXCLUDED.sentiment_key,
+            sentiment_value    = EXCLUDED.sentiment_value,
+            sentiment_compound = EXCLUDED.sentiment_compound,
+            created            = COALESCE(tgt.created, EXCLUDED.created),
+            updated            = EXCLUDED.updated
+        WHERE tgt.updated IS NULL OR tgt.updated < EXCLUDED.updated
+        RETURNING (xmax = 0) AS inserted
+    `
+)
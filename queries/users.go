@@ -0,0 +1,5 @@
+package queries
+
+const (
+	GetUserRoles = `SELECT roles FROM users WHERE lower(email) = lower($1)`
+)
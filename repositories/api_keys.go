@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"golang-restapi/db"
+	"golang-restapi/models"
+	"golang-restapi/queries"
+
+	"github.com/lib/pq"
+)
+
+// ErrAPIKeyNotFound is returned for unknown or revoked keys.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+func scanAPIKey(row rowScanner) (models.APIKey, error) {
+	var k models.APIKey
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, pq.Array(&k.Scopes), &k.RateLimit,
+		&k.RequestCount, &k.LastUsed, &k.Created, &k.Revoked)
+	return k, err
+}
+
+// ListAPIKeys returns every key, revoked ones included.
+func ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.ListAPIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("ListAPIKeys: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ListAPIKeys: scan error: %w", err)
+		}
+		out = append(out, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListAPIKeys: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// CreateAPIKey stores a key by its hash.
+func CreateAPIKey(ctx context.Context, in models.APIKeyInput, prefix, hash string) (models.APIKey, error) {
+	k, err := scanAPIKey(db.DB.QueryRowContext(ctx, queries.InsertAPIKey,
+		in.Name, prefix, hash, pq.Array(in.Scopes), in.RateLimit))
+	if err != nil {
+		return k, fmt.Errorf("CreateAPIKey: query error: %w", err)
+	}
+	return k, nil
+}
+
+// RevokeAPIKey disables a key; revoking twice keeps the first time.
+func RevokeAPIKey(ctx context.Context, id int) error {
+	res, err := db.DB.ExecContext(ctx, queries.RevokeAPIKey, id)
+	if err != nil {
+		return fmt.Errorf("RevokeAPIKey: query error: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// UseAPIKey returns the active key with the given hash and counts its use.
+func UseAPIKey(ctx context.Context, hash string) (models.APIKey, error) {
+	k, err := scanAPIKey(db.DB.QueryRowContext(ctx, queries.UseAPIKey, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return k, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return k, fmt.Errorf("UseAPIKey: query error: %w", err)
+	}
+	return k, nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"golang-restapi/db"
+	"golang-restapi/models"
+	"golang-restapi/queries"
+)
+
+// ErrBackfillJobNotFound is returned when a backfill job id does not exist.
+var ErrBackfillJobNotFound = errors.New("backfill job not found")
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBackfillJob(row rowScanner) (models.BackfillJob, error) {
+	var j models.BackfillJob
+	err := row.Scan(&j.ID, &j.ModelID, &j.FromDate, &j.ToDate, &j.BatchSize, &j.Concurrency,
+		&j.Status, &j.CursorMonth, &j.CursorID, &j.Processed, &j.Scored, &j.Failed, &j.Error,
+		&j.Created, &j.Updated, &j.Started, &j.Finished)
+	return j, err
+}
+
+func listBackfillJobs(ctx context.Context, query string) ([]models.BackfillJob, error) {
+	rows, err := db.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.BackfillJob{}
+	for rows.Next() {
+		j, err := scanBackfillJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		out = append(out, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// ListBackfillJobs returns every job, newest first.
+func ListBackfillJobs(ctx context.Context) ([]models.BackfillJob, error) {
+	out, err := listBackfillJobs(ctx, queries.ListBackfillJobs)
+	if err != nil {
+		return nil, fmt.Errorf("ListBackfillJobs: %w", err)
+	}
+	return out, nil
+}
+
+// ListInterruptedBackfillJobs returns the jobs left in running state.
+func ListInterruptedBackfillJobs(ctx context.Context) ([]models.BackfillJob, error) {
+	out, err := listBackfillJobs(ctx, queries.ListInterruptedBackfillJobs)
+	if err != nil {
+		return nil, fmt.Errorf("ListInterruptedBackfillJobs: %w", err)
+	}
+	return out, nil
+}
+
+func GetBackfillJob(ctx context.Context, id int) (models.BackfillJob, error) {
+	j, err := scanBackfillJob(db.DB.QueryRowContext(ctx, queries.GetBackfillJob, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return j, ErrBackfillJobNotFound
+	}
+	if err != nil {
+		return j, fmt.Errorf("GetBackfillJob: query error: %w", err)
+	}
+	return j, nil
+}
+
+// CreateBackfillJob stores a pending job.
+func CreateBackfillJob(ctx context.Context, in models.BackfillInput) (models.BackfillJob, error) {
+	var id int
+	if err := db.DB.QueryRowContext(ctx, queries.InsertBackfillJob,
+		in.ModelID, in.StartDate, in.EndDate, in.BatchSize, in.Concurrency,
+	).Scan(&id); err != nil {
+		return models.BackfillJob{}, fmt.Errorf("CreateBackfillJob: insert error: %w", err)
+	}
+	return GetBackfillJob(ctx, id)
+}
+
+// SetBackfillStatus moves a job to status; errMsg is stored for failed jobs.
+func SetBackfillStatus(ctx context.Context, id int, status, errMsg string) error {
+	var msg sql.NullString
+	if errMsg != "" {
+		msg = sql.NullString{String: errMsg, Valid: true}
+	}
+	if _, err := db.DB.ExecContext(ctx, queries.SetBackfillStatus, id, status, msg); err != nil {
+		return fmt.Errorf("SetBackfillStatus: update error: %w", err)
+	}
+	return nil
+}
+
+// SetBackfillProgress stores the cursor and the counters of a job.
+func SetBackfillProgress(ctx context.Context, j models.BackfillJob) error {
+	if _, err := db.DB.ExecContext(ctx, queries.SetBackfillProgress,
+		j.ID, j.CursorMonth, j.CursorID, j.Processed, j.Scored, j.Failed,
+	); err != nil {
+		return fmt.Errorf("SetBackfillProgress: update error: %w", err)
+	}
+	return nil
+}
+
+// BackfillFeeds returns the next page of unscored feeds of [from, to) within
+// the job range, after the feed id afterID.
+func BackfillFeeds(ctx context.Context, j models.BackfillJob, from, to string, afterID int64, limit int) ([]models.BackfillFeed, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.BackfillFeeds,
+		from, to, j.FromDate, afterID, j.ModelID, j.ToDate, limit)
+	if err != nil {
+		return nil, fmt.Errorf("BackfillFeeds: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.BackfillFeed{}
+	for rows.Next() {
+		var f models.BackfillFeed
+		if err := rows.Scan(&f.ID, &f.Title, &f.Lang, &f.FeedDate); err != nil {
+			return nil, fmt.Errorf("BackfillFeeds: scan error: %w", err)
+		}
+		out = append(out, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("BackfillFeeds: rows iteration error: %w", err)
+	}
+	return out, nil
+}
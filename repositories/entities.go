@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang-restapi/db"
+	"golang-restapi/models"
+	"golang-restapi/queries"
+	"golang-restapi/utils"
+
+	"github.com/lib/pq"
+)
+
+// entityMention is a single occurrence of an entity in a feed title.
+type entityMention struct {
+	entity   string
+	source   string
+	feedDate time.Time
+	compound sql.NullFloat64
+}
+
+// scanEntityMentions extracts entities from the titles in the date range
+// and calls fn for every mention.
+func scanEntityMentions(
+	ctx context.Context,
+	startDate, endDate, lang string,
+	sources []int,
+	dict *utils.EntityDictionary,
+	fn func(m entityMention),
+) error {
+	stop, err := Stopwords(ctx, lang)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.DB.QueryContext(ctx, queries.EntityMentions, startDate, endDate, pq.Array(sources), lang)
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			title string
+			m     entityMention
+		)
+		if err := rows.Scan(&title, &m.source, &m.feedDate, &m.compound); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		for _, e := range utils.ExtractEntities(title, dict, stop) {
+			m.entity = e
+			fn(m)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %w", err)
+	}
+	return nil
+}
+
+// Entities returns the most mentioned entities with their sources and average compound.
+func Entities(
+	ctx context.Context,
+	startDate, endDate string,
+	lang string,
+	sources []int, // optional
+	limit int,
+) ([]models.EntityRow, error) {
+
+	type agg struct {
+		mentions int
+		sources  map[string]struct{}
+		sum      float64
+		scored   int
+	}
+	byEntity := map[string]*agg{}
+
+	dict, err := LoadEntityDictionary(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Entities: %w", err)
+	}
+
+	err = scanEntityMentions(ctx, startDate, endDate, lang, sources, dict, func(m entityMention) {
+		a, ok := byEntity[m.entity]
+		if !ok {
+			a = &agg{sources: map[string]struct{}{}}
+			byEntity[m.entity] = a
+		}
+		a.mentions++
+		a.sources[m.source] = struct{}{}
+		if m.compound.Valid {
+			a.sum += m.compound.Float64
+			a.scored++
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Entities: %w", err)
+	}
+
+	out := make([]models.EntityRow, 0, len(byEntity))
+	for name, a := range byEntity {
+		if a.mentions < 2 {
+			continue // skip one-off names
+		}
+		r := models.EntityRow{
+			Entity:       name,
+			MentionCount: a.mentions,
+			Sources:      make([]string, 0, len(a.sources)),
+		}
+		for s := range a.sources {
+			r.Sources = append(r.Sources, s)
+		}
+		sort.Strings(r.Sources)
+		if a.scored > 0 {
+			r.AvgCompound = a.sum / float64(a.scored)
+		}
+		out = append(out, r)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].MentionCount != out[j].MentionCount {
+			return out[i].MentionCount > out[j].MentionCount
+		}
+		return out[i].Entity < out[j].Entity
+	})
+
+	if limit < len(out) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// EntityTimeline returns the mention count and average compound of one entity
+// grouped by dateGroup ("day", "week" or "month").
+func EntityTimeline(
+	ctx context.Context,
+	startDate, endDate string,
+	lang string,
+	entity string,
+	dateGroup string,
+	sources []int, // optional
+) ([]models.EntityTimelineRow, error) {
+
+	dict, err := LoadEntityDictionary(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("EntityTimeline: %w", err)
+	}
+	if c, ok := dict.Canonical(entity); ok {
+		entity = c
+	}
+
+	type agg struct {
+		mentions int
+		sum      float64
+		scored   int
+	}
+	byDate := map[string]*agg{}
+
+	err = scanEntityMentions(ctx, startDate, endDate, lang, sources, dict, func(m entityMention) {
+		if m.entity != entity {
+			return
+		}
+		key := truncateDate(m.feedDate, dateGroup).Format("2006-01-02")
+		a, ok := byDate[key]
+		if !ok {
+			a = &agg{}
+			byDate[key] = a
+		}
+		a.mentions++
+		if m.compound.Valid {
+			a.sum += m.compound.Float64
+			a.scored++
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("EntityTimeline: %w", err)
+	}
+
+	out := make([]models.EntityTimelineRow, 0, len(byDate))
+	for date, a := range byDate {
+		r := models.EntityTimelineRow{Date: date, MentionCount: a.mentions}
+		if a.scored > 0 {
+			r.AvgCompound = a.sum / float64(a.scored)
+		}
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date < out[j].Date })
+	return out, nil
+}
+
+// truncateDate returns the first day of the day/ISO week/month t falls into.
+func truncateDate(t time.Time, dateGroup string) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch dateGroup {
+	case "week":
+		offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+		return t.AddDate(0, 0, -offset)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return t
+	}
+}
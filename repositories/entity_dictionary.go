@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang-restapi/db"
+	"golang-restapi/models"
+	"golang-restapi/queries"
+	"golang-restapi/utils"
+)
+
+// ErrEntityNotFound is returned when an entity (or alias) id does not exist.
+var ErrEntityNotFound = errors.New("entity not found")
+
+// scanEntities folds entity x alias rows into entities.
+func scanEntities(rows *sql.Rows) ([]models.Entity, error) {
+	out := []models.Entity{}
+	for rows.Next() {
+		var (
+			e       models.Entity
+			aliasID sql.NullInt64
+			alias   sql.NullString
+			isStem  sql.NullBool
+		)
+		if err := rows.Scan(&e.ID, &e.Name, &e.Kind, &aliasID, &alias, &isStem); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		if n := len(out); n == 0 || out[n-1].ID != e.ID {
+			e.Aliases = []models.EntityAlias{}
+			out = append(out, e)
+		}
+		if aliasID.Valid {
+			last := &out[len(out)-1]
+			last.Aliases = append(last.Aliases, models.EntityAlias{
+				ID:       int(aliasID.Int64),
+				EntityID: e.ID,
+				Alias:    alias.String,
+				IsStem:   isStem.Bool,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// ListEntities returns the whole alias dictionary.
+func ListEntities(ctx context.Context) ([]models.Entity, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.ListEntities)
+	if err != nil {
+		return nil, fmt.Errorf("ListEntities: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out, err := scanEntities(rows)
+	if err != nil {
+		return nil, fmt.Errorf("ListEntities: %w", err)
+	}
+	return out, nil
+}
+
+// GetEntity returns a single entity with its aliases.
+func GetEntity(ctx context.Context, id int) (models.Entity, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.GetEntity, id)
+	if err != nil {
+		return models.Entity{}, fmt.Errorf("GetEntity: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out, err := scanEntities(rows)
+	if err != nil {
+		return models.Entity{}, fmt.Errorf("GetEntity: %w", err)
+	}
+	if len(out) == 0 {
+		return models.Entity{}, ErrEntityNotFound
+	}
+	return out[0], nil
+}
+
+// CreateEntity inserts an entity together with its aliases.
+func CreateEntity(ctx context.Context, in models.EntityInput) (models.Entity, error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Entity{}, fmt.Errorf("CreateEntity: begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+	if err := tx.QueryRowContext(ctx, queries.InsertEntity, strings.TrimSpace(in.Name), entityKind(in.Kind)).Scan(&id); err != nil {
+		return models.Entity{}, fmt.Errorf("CreateEntity: insert error: %w", err)
+	}
+	if err := insertEntityAliases(ctx, tx, id, in.Aliases); err != nil {
+		return models.Entity{}, fmt.Errorf("CreateEntity: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Entity{}, fmt.Errorf("CreateEntity: commit error: %w", err)
+	}
+	return GetEntity(ctx, id)
+}
+
+// UpdateEntity renames an entity and replaces its aliases.
+func UpdateEntity(ctx context.Context, id int, in models.EntityInput) (models.Entity, error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Entity{}, fmt.Errorf("UpdateEntity: begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, queries.UpdateEntity, id, strings.TrimSpace(in.Name), entityKind(in.Kind))
+	if err != nil {
+		return models.Entity{}, fmt.Errorf("UpdateEntity: update error: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return models.Entity{}, ErrEntityNotFound
+	}
+	if _, err := tx.ExecContext(ctx, queries.DeleteEntityAliases, id); err != nil {
+		return models.Entity{}, fmt.Errorf("UpdateEntity: delete aliases error: %w", err)
+	}
+	if err := insertEntityAliases(ctx, tx, id, in.Aliases); err != nil {
+		return models.Entity{}, fmt.Errorf("UpdateEntity: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Entity{}, fmt.Errorf("UpdateEntity: commit error: %w", err)
+	}
+	return GetEntity(ctx, id)
+}
+
+// DeleteEntity removes an entity; its aliases are removed by ON DELETE CASCADE.
+func DeleteEntity(ctx context.Context, id int) error {
+	res, err := db.DB.ExecContext(ctx, queries.DeleteEntity, id)
+	if err != nil {
+		return fmt.Errorf("DeleteEntity: delete error: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrEntityNotFound
+	}
+	return nil
+}
+
+// AddEntityAlias adds an alias or stem to an existing entity.
+func AddEntityAlias(ctx context.Context, entityID int, in models.EntityAliasInput) (models.EntityAlias, error) {
+	if _, err := GetEntity(ctx, entityID); err != nil {
+		return models.EntityAlias{}, err
+	}
+
+	out := models.EntityAlias{EntityID: entityID, Alias: strings.TrimSpace(in.Alias), IsStem: in.IsStem}
+	if err := db.DB.QueryRowContext(ctx, queries.InsertEntityAlias, entityID, out.Alias, out.IsStem).Scan(&out.ID); err != nil {
+		return models.EntityAlias{}, fmt.Errorf("AddEntityAlias: insert error: %w", err)
+	}
+	return out, nil
+}
+
+// DeleteEntityAlias removes one alias of an entity.
+func DeleteEntityAlias(ctx context.Context, entityID, aliasID int) error {
+	res, err := db.DB.ExecContext(ctx, queries.DeleteEntityAlias, entityID, aliasID)
+	if err != nil {
+		return fmt.Errorf("DeleteEntityAlias: delete error: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrEntityNotFound
+	}
+	return nil
+}
+
+// LoadEntityDictionary builds the extractor dictionary from the entities table.
+func LoadEntityDictionary(ctx context.Context) (*utils.EntityDictionary, error) {
+	entities, err := ListEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dict := utils.NewEntityDictionary(nil)
+	for _, e := range entities {
+		aliases := make([]string, 0, len(e.Aliases))
+		for _, a := range e.Aliases {
+			if a.IsStem {
+				aliases = append(aliases, a.Alias+"*")
+			} else {
+				aliases = append(aliases, a.Alias)
+			}
+		}
+		dict.Add(e.Name, aliases...)
+	}
+	return dict, nil
+}
+
+// searchTerms returns the label and the terms to search for: the word itself,
+// or the name and every alias of the entity when entityID is set.
+func searchTerms(ctx context.Context, word string, entityID int) (string, []utils.SearchTerm, error) {
+	if entityID <= 0 {
+		return word, []utils.SearchTerm{{Text: word}}, nil
+	}
+
+	e, err := GetEntity(ctx, entityID)
+	if err != nil {
+		return "", nil, err
+	}
+	terms := []utils.SearchTerm{{Text: e.Name}}
+	for _, a := range e.Aliases {
+		terms = append(terms, utils.SearchTerm{Text: a.Alias, Stem: a.IsStem})
+	}
+	return e.Name, terms, nil
+}
+
+func insertEntityAliases(ctx context.Context, tx *sql.Tx, entityID int, aliases []models.EntityAliasInput) error {
+	for _, a := range aliases {
+		alias := strings.TrimSpace(a.Alias)
+		if alias == "" {
+			continue
+		}
+		var aliasID int
+		if err := tx.QueryRowContext(ctx, queries.InsertEntityAlias, entityID, alias, a.IsStem).Scan(&aliasID); err != nil {
+			return fmt.Errorf("insert alias error: %w", err)
+		}
+	}
+	return nil
+}
+
+func entityKind(kind string) string {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		return "person"
+	}
+	return kind
+}
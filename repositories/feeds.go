@@ -17,6 +17,7 @@ func GetFeeds(
 	startDate, endDate string,
 	sources []int,
 	freeText string,
+	lang string, // optional
 	page, perPage int,
 ) (models.FeedResponse, error) {
 
@@ -38,6 +39,12 @@ func GetFeeds(
 		idx++
 	}
 
+	if lang != "" {
+		conds = append(conds, fmt.Sprintf("AND f.lang = $%d", idx))
+		args = append(args, lang)
+		idx++
+	}
+
 	whereClause := ""
 	if len(conds) > 0 {
 		whereClause = " " + strings.Join(conds, " ")
@@ -76,7 +83,7 @@ func GetFeeds(
 		if err := rows.Scan(
 			&f.ID, &f.Title, &f.Link, &f.SourceID, pq.Array(&f.Words), &f.Published,
 			&fs.ID, &fs.SentimentKey, &fs.SentimentValue, &fs.SentimentCompound,
-			&s.ID, &s.Name,
+			&s.ID, &s.Name, &s.Lang,
 		); err != nil {
 			return resp, fmt.Errorf("GetFeeds: scan error: %w", err)
 		}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"golang-restapi/db"
+)
+
+// Ping checks that the database accepts connections.
+func Ping(ctx context.Context) error {
+	if err := db.DB.PingContext(ctx); err != nil {
+		return fmt.Errorf("Ping: database error: %w", err)
+	}
+	return nil
+}
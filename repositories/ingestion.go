@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang-restapi/db"
+	"golang-restapi/models"
+	"golang-restapi/queries"
+	"golang-restapi/utils"
+
+	"github.com/lib/pq"
+)
+
+// ListFeedSources returns the sources the ingestion worker polls.
+func ListFeedSources(ctx context.Context) ([]models.FeedSource, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.ListFeedSources)
+	if err != nil {
+		return nil, fmt.Errorf("ListFeedSources: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.FeedSource{}
+	for rows.Next() {
+		var s models.FeedSource
+		if err := rows.Scan(&s.ID, &s.Name, &s.Lang, &s.RSSURL); err != nil {
+			return nil, fmt.Errorf("ListFeedSources: scan error: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListFeedSources: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// MarkSourcePolled records the time of the last successful poll.
+func MarkSourcePolled(ctx context.Context, sourceID int) error {
+	if _, err := db.DB.ExecContext(ctx, queries.MarkSourcePolled, sourceID); err != nil {
+		return fmt.Errorf("MarkSourcePolled: update error: %w", err)
+	}
+	return nil
+}
+
+// InsertFeed inserts a feed. inserted=false if it already exists
+// (same source_id, link and feed_date).
+func InsertFeed(ctx context.Context, f models.NewFeed) (id int, inserted bool, err error) {
+	err = db.DB.QueryRowContext(ctx, queries.InsertFeed,
+		f.Title,                 // $1
+		f.Link,                  // $2
+		f.SourceID,              // $3
+		f.Lang,                  // $4
+		pq.Array(f.Words),       // $5
+		pq.Array(f.WordsMasked), // $6
+		f.Published,             // $7
+		f.FeedDate,              // $8
+		utils.TSConfig(f.Lang),  // $9
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("InsertFeed: insert error: %w", err)
+	}
+	return id, true, nil
+}
+
+// UpsertFeedSentiments writes the sentiment scores in one transaction.
+func UpsertFeedSentiments(ctx context.Context, rows []models.NewFeedSentiment) error {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("UpsertFeedSentiments: begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, queries.UpsertFeedSentiment)
+	if err != nil {
+		return fmt.Errorf("UpsertFeedSentiments: prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, r := range rows {
+		sentiments, err := json.Marshal(r.Sentiments)
+		if err != nil {
+			return fmt.Errorf("UpsertFeedSentiments: marshal error: %w", err)
+		}
+		if _, err := stmt.ExecContext(ctx,
+			r.FeedID,            // $1
+			r.ModelID,           // $2
+			string(sentiments),  // $3
+			r.SentimentKey,      // $4
+			r.SentimentValue,    // $5
+			r.SentimentCompound, // $6
+			r.FeedDate,          // $7
+		); err != nil {
+			return fmt.Errorf("UpsertFeedSentiments: upsert error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("UpsertFeedSentiments: commit error: %w", err)
+	}
+	return nil
+}
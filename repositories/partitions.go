@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"golang-restapi/db"
+	"golang-restapi/models"
+	"golang-restapi/queries"
+
+	"github.com/lib/pq"
+)
+
+var partitionBound = regexp.MustCompile(`FROM \('([0-9-]+)[^']*'\) TO \('([0-9-]+)[^']*'\)`)
+
+// ListPartitions returns the range partitions of a public table. The default
+// partition (if any) has no bounds and is skipped.
+func ListPartitions(ctx context.Context, table string) ([]models.Partition, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.ListPartitions, table)
+	if err != nil {
+		return nil, fmt.Errorf("ListPartitions: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.Partition{}
+	for rows.Next() {
+		var name, bound string
+		if err := rows.Scan(&name, &bound); err != nil {
+			return nil, fmt.Errorf("ListPartitions: scan error: %w", err)
+		}
+		m := partitionBound.FindStringSubmatch(bound)
+		if m == nil {
+			continue
+		}
+		out = append(out, models.Partition{Name: name, From: m[1], To: m[2]})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListPartitions: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// CreateMonthlyPartition creates <table>_YYYY_MM for the month of month.
+func CreateMonthlyPartition(ctx context.Context, table string, month time.Time) (string, error) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	name := fmt.Sprintf("%s_%s", table, from.Format("2006_01"))
+
+	stmt := fmt.Sprintf(queries.CreateMonthlyPartition,
+		pq.QuoteIdentifier(name), pq.QuoteIdentifier(table),
+		from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if _, err := db.DB.ExecContext(ctx, stmt); err != nil {
+		return "", fmt.Errorf("CreateMonthlyPartition: %s: %w", name, err)
+	}
+	return name, nil
+}
+
+// DetachPartition detaches a partition; the table and its rows are kept.
+func DetachPartition(ctx context.Context, table, partition string) error {
+	stmt := fmt.Sprintf(queries.DetachPartition, pq.QuoteIdentifier(table), pq.QuoteIdentifier(partition))
+	if _, err := db.DB.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("DetachPartition: %s: %w", partition, err)
+	}
+	return nil
+}
+
+// ArchivePartition detaches a partition and moves it to the archive schema.
+func ArchivePartition(ctx context.Context, table, partition, schema string) error {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ArchivePartition: begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range []string{
+		fmt.Sprintf(queries.CreateArchiveSchema, pq.QuoteIdentifier(schema)),
+		fmt.Sprintf(queries.DetachPartition, pq.QuoteIdentifier(table), pq.QuoteIdentifier(partition)),
+		fmt.Sprintf(queries.MovePartitionToSchema, pq.QuoteIdentifier(partition), pq.QuoteIdentifier(schema)),
+	} {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("ArchivePartition: %s: %w", partition, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ArchivePartition: commit error: %w", err)
+	}
+	return nil
+}
@@ -17,10 +17,16 @@ import (
 	// "golang-restapi/sentimentpb"
 )
 
-// MostCommonWords fetches feed words, filters stopwords, and counts occurrences.
-func MostCommonWords(ctx context.Context, startDate, endDate string, n int) ([]models.WordCount, error) {
+// MostCommonWords fetches feed words, filters stopwords, and counts occurrences
+// of the normalized forms.
+func MostCommonWords(ctx context.Context, startDate, endDate, lang string, n int, norm utils.Normalizer) ([]models.WordCount, error) {
 
-	rows, err := db.DB.QueryContext(ctx, queries.GetWordsByDateRange, startDate, endDate)
+	stop, err := Stopwords(ctx, lang)
+	if err != nil {
+		return nil, fmt.Errorf("MostCommonWords: %w", err)
+	}
+
+	rows, err := db.DB.QueryContext(ctx, queries.GetWordsByDateRange, startDate, endDate, lang)
 	if err != nil {
 		return nil, fmt.Errorf("MostCommonWords: query error: %w", err)
 	}
@@ -38,7 +44,11 @@ func MostCommonWords(ctx context.Context, startDate, endDate string, n int) ([]m
 				continue
 			}
 			word := w.String
-			if utils.IsStopword(word) {
+			if stop.IsStopword(word) {
+				continue
+			}
+			word = norm.Normalize(word)
+			if stop.IsStopword(word) {
 				continue
 			}
 			counts[word]++
@@ -167,22 +177,42 @@ func TopFeeds(ctx context.Context, startDate, endDate, posNeg string, limit int)
 	return out, nil
 }
 
-// BiasDetection
+// BiasDetection compares the net sentiment per source for a word,
+// or for every alias of an entity when entityID is set.
 func BiasDetection(
 	ctx context.Context,
 	startDate, endDate string,
+	lang string,
 	word string,
+	entityID int, // optional, overrides word
 ) ([]models.BiasDetectionRow, error) {
 
-	w := utils.SanitizeTSWord(word)
-	if w == "" || strings.Contains(w, " ") {
-		return nil, fmt.Errorf("BiasDetection: 'word' must be a single non-empty string")
+	var tsq string
+	if entityID > 0 {
+		label, terms, err := searchTerms(ctx, word, entityID)
+		if err != nil {
+			return nil, err
+		}
+		q, ok := utils.BuildTermsTSQuery(terms, true)
+		if !ok {
+			return nil, fmt.Errorf("BiasDetection: entity %d has no usable aliases", entityID)
+		}
+		word, tsq = label, q
+	} else {
+		w := utils.SanitizeTSWord(word)
+		if w == "" || strings.Contains(w, " ") {
+			return nil, fmt.Errorf("BiasDetection: 'word' must be a single non-empty string")
+		}
+		word, tsq = w, w+":*"
 	}
 
 	args := []any{
-		w,                       // $1
+		word,                    // $1
 		startDate + " 00:00:00", // $2
 		endDate + " 23:59:59",   // $3
+		tsq,                     // $4
+		lang,                    // $5
+		utils.TSConfig(lang),    // $6
 	}
 
 	sql := fmt.Sprintf(queries.BiasDetection)
@@ -217,23 +247,41 @@ func BiasDetection(
 func CorrelationBetweenSourcesAvgCompound(
 	ctx context.Context,
 	startDate, endDate string,
+	lang string,
 	word string,
+	entityID int, // optional, overrides word
 	sources []int, // optional
 ) ([]models.CorrelationRow, error) {
 
-	w := utils.SanitizeTSWord(word)
-	if w == "" || strings.Contains(w, " ") {
-		return nil, fmt.Errorf("correlation: 'word' must be a single non-empty string")
+	var tsq string
+	if entityID > 0 {
+		_, terms, err := searchTerms(ctx, word, entityID)
+		if err != nil {
+			return nil, err
+		}
+		q, ok := utils.BuildTermsTSQuery(terms, false)
+		if !ok {
+			return nil, fmt.Errorf("correlation: entity %d has no usable aliases", entityID)
+		}
+		tsq = q
+	} else {
+		w := utils.SanitizeTSWord(word)
+		if w == "" || strings.Contains(w, " ") {
+			return nil, fmt.Errorf("correlation: 'word' must be a single non-empty string")
+		}
+		tsq = w
 	}
 
 	args := []any{
-		w,                       // $1
+		tsq,                     // $1
 		startDate + " 00:00:00", // $2
 		endDate + " 23:59:59",   // $3
+		utils.TSConfig(lang),    // $4
+		lang,                    // $5
 	}
 	extra := ""
 	if len(sources) > 0 {
-		extra = " AND f.source_id = ANY($4)"
+		extra = " AND f.source_id = ANY($6)"
 		args = append(args, pq.Array(sources))
 	}
 
@@ -261,31 +309,66 @@ func CorrelationBetweenSourcesAvgCompound(
 	return out, nil
 }
 
-// WordCoOccurrences returns the top co-occurring words with a given `word`.
+// wordCoOccurrencesLimit is the number of co-words WordCoOccurrences returns.
+const wordCoOccurrencesLimit = 30
+
+// WordCoOccurrences returns the top co-occurring words with a given `word`,
+// or with any alias of an entity when entityID is set. Co-words are merged by
+// their normalized form.
 func WordCoOccurrences(
 	ctx context.Context,
 	startDate, endDate string,
+	lang string,
 	word string,
+	entityID int, // optional, overrides word
 	sources []int, // optional
+	norm utils.Normalizer,
 ) ([]models.WordCoOccurrenceRow, error) {
-	if word == "" {
+	if word == "" && entityID <= 0 {
 		return nil, fmt.Errorf("word is required")
 	}
 
+	_, terms, err := searchTerms(ctx, word, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	stop, err := Stopwords(ctx, lang)
+	if err != nil {
+		return nil, fmt.Errorf("WordCoOccurrences: %w", err)
+	}
+
+	_, noop := norm.(utils.NoopNormalizer)
+
+	var target any = word
+	targetCond := queries.WordCoOccurrencesByWord
+	switch {
+	case entityID > 0:
+		target = pq.Array(utils.RegexPatterns(terms, false))
+		targetCond = queries.WordCoOccurrencesByEntity
+	case !noop:
+		// match every surface form of the normalized word
+		terms = []utils.SearchTerm{{Text: norm.Normalize(word), Stem: true}}
+		target = pq.Array(utils.RegexPatterns(terms, true))
+		targetCond = queries.WordCoOccurrencesByStem
+	}
+
 	args := []any{
-		word,                          // $1
-		startDate + " 00:00:00",       // $2
-		endDate + " 23:59:59",         // $3
-		pq.Array(utils.StopWordsList), // $4
+		target,                  // $1
+		startDate + " 00:00:00", // $2
+		endDate + " 23:59:59",   // $3
+		pq.Array(stop.Words()),  // $4
+		pq.Array(utils.RegexPatterns(terms, true)), // $5
+		lang, // $6
 	}
 
 	extra := ""
 	if len(sources) > 0 {
-		extra = " AND f.source_id = ANY($5)"
+		extra = " AND f.source_id = ANY($7)"
 		args = append(args, pq.Array(sources))
 	}
 
-	sql := fmt.Sprintf(queries.WordCoOccurrences, extra)
+	sql := fmt.Sprintf(queries.WordCoOccurrences, targetCond, extra)
 
 	rows, err := db.DB.QueryContext(ctx, sql, args...)
 
@@ -294,7 +377,8 @@ func WordCoOccurrences(
 	}
 	defer rows.Close()
 
-	out := make([]models.WordCoOccurrenceRow, 0, 64)
+	// merge the co-words by their normalized form
+	byWord := map[string]*models.WordCoOccurrenceRow{}
 	for rows.Next() {
 		var r models.WordCoOccurrenceRow
 		if err := rows.Scan(
@@ -306,11 +390,36 @@ func WordCoOccurrences(
 		); err != nil {
 			return nil, fmt.Errorf("WordCoOccurrences: scan error: %w", err)
 		}
-		out = append(out, r)
+		key := norm.Normalize(r.CoWord)
+		if m, ok := byWord[key]; ok {
+			m.CoOccurrence += r.CoOccurrence
+			m.PositiveCount += r.PositiveCount
+			m.NegativeCount += r.NegativeCount
+			m.NeutralCount += r.NeutralCount
+			continue
+		}
+		r.CoWord = key
+		byWord[key] = &r
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("WordCoOccurrences: rows iteration error: %w", err)
 	}
+
+	out := make([]models.WordCoOccurrenceRow, 0, len(byWord))
+	for _, r := range byWord {
+		if r.CoOccurrence > 1 {
+			out = append(out, *r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CoOccurrence != out[j].CoOccurrence {
+			return out[i].CoOccurrence > out[j].CoOccurrence
+		}
+		return out[i].CoWord < out[j].CoWord
+	})
+	if len(out) > wordCoOccurrencesLimit {
+		out = out[:wordCoOccurrencesLimit]
+	}
 	return out, nil
 }
 
@@ -318,20 +427,27 @@ func WordCoOccurrences(
 func PhraseFrequencyTrends(
 	ctx context.Context,
 	startDate, endDate, dateGroup string,
+	lang string,
 	sources []int, // optional
 	namesExcluded bool, // use bool instead of string
 ) ([]models.PhraseFrequencyRow, error) {
 
+	stop, err := Stopwords(ctx, lang)
+	if err != nil {
+		return nil, fmt.Errorf("PhraseFrequencyTrends: %w", err)
+	}
+
 	// Ensure you always pass arrays (empty is fine).
-	// Param order maps to $1..$7 in SQL above.
+	// Param order maps to $1..$8 in SQL above.
 	args := []any{
-		startDate + " 00:00:00",          // $1
-		endDate + " 23:59:59",            // $2
-		dateGroup,                        // $3 ("week" or "month")
-		pq.Array(utils.StopwordsSimple),  // $4 ::text[]
-		namesExcluded,                    // $5 ::boolean
-		pq.Array(utils.StopPhrasessList), // $6 ::text[] (used only if $5 = true)
-		pq.Array(sources),                // $7 ::int[]   (empty => no filter)
+		startDate + " 00:00:00",  // $1
+		endDate + " 23:59:59",    // $2
+		dateGroup,                // $3 ("week" or "month")
+		pq.Array(stop.Words()),   // $4 ::text[]
+		namesExcluded,            // $5 ::boolean
+		pq.Array(stop.Phrases()), // $6 ::text[] (used only if $5 = true)
+		pq.Array(sources),        // $7 ::int[]   (empty => no filter)
+		lang,                     // $8
 	}
 
 	rows, err := db.DB.QueryContext(ctx, queries.PhraseFrequencyTrendsNew, args...)
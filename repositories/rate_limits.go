@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"golang-restapi/db"
+	"golang-restapi/queries"
+)
+
+// TakeRateLimitTokens takes cost tokens from the shared bucket of key and
+// returns whether it held enough and the tokens left.
+func TakeRateLimitTokens(ctx context.Context, key string, cost, capacity int, rate float64) (bool, float64, error) {
+	var tokens float64
+	var allowed bool
+	err := db.DB.QueryRowContext(ctx, queries.TakeRateLimitTokens, key, capacity, cost, rate).Scan(&tokens, &allowed)
+	if err != nil {
+		return false, 0, fmt.Errorf("TakeRateLimitTokens: query error: %w", err)
+	}
+	return allowed, tokens, nil
+}
+
+// PurgeRateLimitBuckets deletes buckets idle for a day.
+func PurgeRateLimitBuckets(ctx context.Context) (int64, error) {
+	res, err := db.DB.ExecContext(ctx, queries.PurgeRateLimitBuckets)
+	if err != nil {
+		return 0, fmt.Errorf("PurgeRateLimitBuckets: query error: %w", err)
+	}
+	return res.RowsAffected()
+}
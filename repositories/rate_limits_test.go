@@ -0,0 +1,58 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"golang-restapi/db/dbtest"
+	"golang-restapi/repositories"
+)
+
+func TestTakeRateLimitTokens(t *testing.T) {
+	conn := dbtest.Open(t)
+	ctx := context.Background()
+
+	type step struct {
+		cost    int
+		allowed bool
+		tokens  float64
+	}
+	// capacity 10, no refill: the tokens left are exact
+	for i, st := range []step{
+		{4, true, 6},  // new bucket
+		{6, true, 0},  // to zero
+		{1, false, 0}, // denied, nothing taken
+	} {
+		allowed, tokens, err := repositories.TakeRateLimitTokens(ctx, "user:a", st.cost, 10, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed != st.allowed || tokens != st.tokens {
+			t.Errorf("step %d: allowed %v tokens %v, want %v %v", i+1, allowed, tokens, st.allowed, st.tokens)
+		}
+	}
+
+	// a minute ago at one token a second: refilled to the capacity
+	if _, err := conn.ExecContext(ctx, `UPDATE rate_limit_buckets SET updated = now() - interval '1 minute' WHERE key = 'user:a'`); err != nil {
+		t.Fatal(err)
+	}
+	allowed, tokens, err := repositories.TakeRateLimitTokens(ctx, "user:a", 1, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !allowed || tokens != 9 {
+		t.Errorf("after refill: allowed %v tokens %v, want true 9", allowed, tokens)
+	}
+
+	// other keys have their own bucket
+	if allowed, tokens, _ := repositories.TakeRateLimitTokens(ctx, "user:b", 10, 10, 0); !allowed || tokens != 0 {
+		t.Errorf("user:b: allowed %v tokens %v", allowed, tokens)
+	}
+
+	if _, err := conn.ExecContext(ctx, `UPDATE rate_limit_buckets SET updated = now() - interval '2 days' WHERE key = 'user:b'`); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := repositories.PurgeRateLimitBuckets(ctx); err != nil || n != 1 {
+		t.Errorf("PurgeRateLimitBuckets = %d, %v, want 1", n, err)
+	}
+}
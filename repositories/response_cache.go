@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"golang-restapi/db"
+	"golang-restapi/queries"
+
+	"github.com/lib/pq"
+)
+
+// ResponseCacheChannel is the NOTIFY channel of feed_date ranges written
+// outside the API process, e.g. by the sync command.
+const ResponseCacheChannel = "response_cache_invalidate"
+
+// NotifyResponseCache tells the API servers that the feeds or sentiments
+// of [from, to] (YYYY-MM-DD) changed.
+func NotifyResponseCache(ctx context.Context, from, to string) error {
+	if _, err := db.DB.ExecContext(ctx, queries.NotifyResponseCache, ResponseCacheChannel, from+","+to); err != nil {
+		return fmt.Errorf("NotifyResponseCache: notify error: %w", err)
+	}
+	return nil
+}
+
+// ListenResponseCache calls invalidate with the ranges notified by
+// NotifyResponseCache until ctx is done. Notifications sent while the
+// connection was down are lost, so every range is invalidated on reconnect.
+func ListenResponseCache(ctx context.Context, dsn string, invalidate func(from, to string)) {
+	l := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.WarnContext(ctx, "response cache listener", slog.Int("event", int(ev)), slog.Any("error", err))
+		}
+	})
+	defer l.Close()
+	if err := l.Listen(ResponseCacheChannel); err != nil {
+		slog.ErrorContext(ctx, "response cache listener not started", slog.Any("error", err))
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-l.Notify:
+			if n == nil {
+				// reconnected
+				invalidate("0000-01-01", "9999-12-31")
+				continue
+			}
+			from, to, ok := strings.Cut(n.Extra, ",")
+			if !ok {
+				slog.WarnContext(ctx, "response cache listener: bad payload", slog.String("payload", n.Extra))
+				continue
+			}
+			invalidate(from, to)
+		case <-time.After(90 * time.Second):
+			// detect a dead connection the server did not close
+			go l.Ping()
+		}
+	}
+}
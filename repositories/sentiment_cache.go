@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golang-restapi/db"
+	"golang-restapi/queries"
+	"golang-restapi/utils"
+
+	"github.com/lib/pq"
+)
+
+// SentimentCache is the Postgres level of the sentiment cache
+// (a utils.SentimentCacheStore).
+type SentimentCache struct {
+	ModelVersion string
+	TTL          time.Duration
+}
+
+func (s SentimentCache) GetSentiments(ctx context.Context, keys []string) (map[string]utils.SentimentResult, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.GetCachedSentiments, pq.Array(keys), s.ttlSeconds())
+	if err != nil {
+		return nil, fmt.Errorf("GetSentiments: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string]utils.SentimentResult{}
+	for rows.Next() {
+		var key string
+		var r utils.SentimentResult
+		var scores []byte
+		if err := rows.Scan(&key, &r.Key, &r.Value, &scores); err != nil {
+			return nil, fmt.Errorf("GetSentiments: scan error: %w", err)
+		}
+		if err := json.Unmarshal(scores, &r.Scores); err != nil {
+			return nil, fmt.Errorf("GetSentiments: unmarshal error: %w", err)
+		}
+		out[key] = r
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetSentiments: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+func (s SentimentCache) PutSentiments(ctx context.Context, results map[string]utils.SentimentResult) error {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("PutSentiments: begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, queries.PutCachedSentiment)
+	if err != nil {
+		return fmt.Errorf("PutSentiments: prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	for key, r := range results {
+		scores, err := json.Marshal(r.Scores)
+		if err != nil {
+			return fmt.Errorf("PutSentiments: marshal error: %w", err)
+		}
+		if _, err := stmt.ExecContext(ctx, key, s.ModelVersion, r.Key, r.Value, string(scores)); err != nil {
+			return fmt.Errorf("PutSentiments: upsert error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PutSentiments: commit error: %w", err)
+	}
+	return nil
+}
+
+// ttlSeconds is the TTL for make_interval; no TTL keeps rows for a century.
+func (s SentimentCache) ttlSeconds() float64 {
+	if s.TTL <= 0 {
+		return (100 * 365 * 24 * time.Hour).Seconds()
+	}
+	return s.TTL.Seconds()
+}
+
+// Purge deletes the rows of other model versions and the expired ones.
+func (s SentimentCache) Purge(ctx context.Context) (int64, error) {
+	res, err := db.DB.ExecContext(ctx, queries.PurgeSentimentCache, s.ModelVersion, s.ttlSeconds())
+	if err != nil {
+		return 0, fmt.Errorf("PurgeSentimentCache: delete error: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
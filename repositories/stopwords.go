@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-restapi/db"
+	"golang-restapi/models"
+	"golang-restapi/queries"
+	"golang-restapi/utils"
+)
+
+// ErrStopwordNotFound is returned when deleting a stopword that does not exist.
+var ErrStopwordNotFound = errors.New("stopword not found")
+
+// stopwordsTTL bounds how stale the cache can get when another instance edits the table.
+const stopwordsTTL = 5 * time.Minute
+
+var (
+	stopwordsMu     sync.RWMutex
+	stopwordsByLang map[string]*utils.StopwordSet
+	stopwordsLoaded time.Time
+)
+
+// Stopwords returns the cached stopword set of a language, loading the
+// stopwords table on first use and after stopwordsTTL.
+func Stopwords(ctx context.Context, lang string) (*utils.StopwordSet, error) {
+	stopwordsMu.RLock()
+	fresh := stopwordsByLang != nil && time.Since(stopwordsLoaded) < stopwordsTTL
+	set := stopwordsByLang[lang]
+	stopwordsMu.RUnlock()
+
+	if !fresh {
+		if err := ReloadStopwords(ctx); err != nil {
+			return nil, err
+		}
+		stopwordsMu.RLock()
+		set = stopwordsByLang[lang]
+		stopwordsMu.RUnlock()
+	}
+
+	if set == nil {
+		set = utils.NewStopwordSet(nil, nil)
+	}
+	return set, nil
+}
+
+// ReloadStopwords replaces the cache with the current content of the stopwords table.
+func ReloadStopwords(ctx context.Context) error {
+	rows, err := ListStopwords(ctx, "", "")
+	if err != nil {
+		return fmt.Errorf("ReloadStopwords: %w", err)
+	}
+
+	words := map[string][]string{}
+	phrases := map[string][]string{}
+	for _, r := range rows {
+		if r.Kind == "phrase" {
+			phrases[r.Lang] = append(phrases[r.Lang], r.Word)
+		} else {
+			words[r.Lang] = append(words[r.Lang], r.Word)
+		}
+	}
+
+	byLang := map[string]*utils.StopwordSet{}
+	for lang := range words {
+		byLang[lang] = utils.NewStopwordSet(words[lang], phrases[lang])
+	}
+	for lang := range phrases {
+		if _, ok := byLang[lang]; !ok {
+			byLang[lang] = utils.NewStopwordSet(nil, phrases[lang])
+		}
+	}
+
+	stopwordsMu.Lock()
+	stopwordsByLang = byLang
+	stopwordsLoaded = time.Now()
+	stopwordsMu.Unlock()
+	return nil
+}
+
+// ListStopwords returns the stopwords table; empty lang/kind means no filter.
+func ListStopwords(ctx context.Context, lang, kind string) ([]models.Stopword, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.ListStopwords, lang, kind)
+	if err != nil {
+		return nil, fmt.Errorf("ListStopwords: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.Stopword{}
+	for rows.Next() {
+		var r models.Stopword
+		if err := rows.Scan(&r.Lang, &r.Word, &r.Kind); err != nil {
+			return nil, fmt.Errorf("ListStopwords: scan error: %w", err)
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListStopwords: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// AddStopwords inserts words (existing ones are skipped) and reloads the cache.
+func AddStopwords(ctx context.Context, lang, kind string, words []string) ([]models.Stopword, error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("AddStopwords: begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	out := make([]models.Stopword, 0, len(words))
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, queries.InsertStopword, lang, w, kind); err != nil {
+			return nil, fmt.Errorf("AddStopwords: insert error: %w", err)
+		}
+		out = append(out, models.Stopword{Lang: lang, Word: w, Kind: kind})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("AddStopwords: commit error: %w", err)
+	}
+	return out, ReloadStopwords(ctx)
+}
+
+// DeleteStopword removes a single stopword and reloads the cache.
+func DeleteStopword(ctx context.Context, lang, word, kind string) error {
+	res, err := db.DB.ExecContext(ctx, queries.DeleteStopword, lang, strings.ToLower(word), kind)
+	if err != nil {
+		return fmt.Errorf("DeleteStopword: delete error: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrStopwordNotFound
+	}
+	return ReloadStopwords(ctx)
+}
@@ -0,0 +1,314 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang-restapi/db"
+	"golang-restapi/models"
+	"golang-restapi/queries"
+	"golang-restapi/utils"
+
+	"github.com/lib/pq"
+)
+
+// SyncHighWater returns the high-water mark of a synced table
+// (the zero time if it was never synced).
+func SyncHighWater(ctx context.Context, name string) (time.Time, error) {
+	var hw time.Time
+	err := db.DB.QueryRowContext(ctx, queries.GetSyncHighWater, name).Scan(&hw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("SyncHighWater: query error: %w", err)
+	}
+	return hw, nil
+}
+
+func SetSyncHighWater(ctx context.Context, name string, hw time.Time) error {
+	if _, err := db.DB.ExecContext(ctx, queries.SetSyncHighWater, name, hw); err != nil {
+		return fmt.Errorf("SetSyncHighWater: upsert error: %w", err)
+	}
+	return nil
+}
+
+// SourceNow returns the current time of the source database.
+func SourceNow(ctx context.Context, src *sql.DB) (time.Time, error) {
+	var now time.Time
+	if err := src.QueryRowContext(ctx, queries.SourceNow).Scan(&now); err != nil {
+		return time.Time{}, fmt.Errorf("SourceNow: query error: %w", err)
+	}
+	return now, nil
+}
+
+// SourceChangedMonths returns the months with changes in the source database
+// between the high-water marks and upTo.
+func SourceChangedMonths(ctx context.Context, src *sql.DB, feedsHW, sentimentsHW, upTo time.Time) ([]time.Time, error) {
+	rows, err := src.QueryContext(ctx, queries.SourceChangedMonths, feedsHW, sentimentsHW, upTo)
+	if err != nil {
+		return nil, fmt.Errorf("SourceChangedMonths: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []time.Time{}
+	for rows.Next() {
+		var month time.Time
+		if err := rows.Scan(&month); err != nil {
+			return nil, fmt.Errorf("SourceChangedMonths: scan error: %w", err)
+		}
+		out = append(out, month)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SourceChangedMonths: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// SourceFeeds returns the source feeds of [from, to) updated in (since, upTo].
+func SourceFeeds(ctx context.Context, src *sql.DB, from, to, since, upTo time.Time) ([]models.SyncFeed, error) {
+	rows, err := src.QueryContext(ctx, queries.SourceFeeds, dateParam(from), dateParam(to), since, upTo)
+	if err != nil {
+		return nil, fmt.Errorf("SourceFeeds: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.SyncFeed{}
+	for rows.Next() {
+		var f models.SyncFeed
+		if err := rows.Scan(&f.Title, &f.Link, &f.SourceID, pq.Array(&f.Words), &f.Published,
+			&f.FeedDate, &f.CategoryID, &f.Created, &f.Updated); err != nil {
+			return nil, fmt.Errorf("SourceFeeds: scan error: %w", err)
+		}
+		out = append(out, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SourceFeeds: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// SourceFeedSentiments returns the source sentiments of [from, to) updated in (since, upTo].
+func SourceFeedSentiments(ctx context.Context, src *sql.DB, from, to, since, upTo time.Time) ([]models.SyncFeedSentiment, error) {
+	rows, err := src.QueryContext(ctx, queries.SourceFeedSentiments, dateParam(from), dateParam(to), since, upTo)
+	if err != nil {
+		return nil, fmt.Errorf("SourceFeedSentiments: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.SyncFeedSentiment{}
+	for rows.Next() {
+		var s models.SyncFeedSentiment
+		if err := rows.Scan(&s.SourceID, &s.Link, &s.FeedDate, &s.ModelID, &s.Sentiments,
+			&s.SentimentKey, &s.SentimentValue, &s.SentimentCompound, &s.SentimentDate,
+			&s.Created, &s.Updated); err != nil {
+			return nil, fmt.Errorf("SourceFeedSentiments: scan error: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SourceFeedSentiments: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// FeedKey is the natural key of a feed, stable across databases.
+func FeedKey(sourceID int, link string, feedDate time.Time) string {
+	return fmt.Sprintf("%d|%s|%s", sourceID, link, feedDate.Format("2006-01-02"))
+}
+
+// dateParam formats a date parameter as YYYY-MM-DD; a time.Time would be
+// cast to date in the session time zone and could shift by a day.
+func dateParam(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// TargetFeedIDs maps the FeedKey of the target feeds of [from, to) to their id.
+func TargetFeedIDs(ctx context.Context, from, to time.Time) (map[string]int64, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.TargetFeedKeys, dateParam(from), dateParam(to))
+	if err != nil {
+		return nil, fmt.Errorf("TargetFeedIDs: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string]int64{}
+	for rows.Next() {
+		var id int64
+		var sourceID int
+		var link string
+		var feedDate time.Time
+		if err := rows.Scan(&id, &sourceID, &link, &feedDate); err != nil {
+			return nil, fmt.Errorf("TargetFeedIDs: scan error: %w", err)
+		}
+		out[FeedKey(sourceID, link, feedDate)] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("TargetFeedIDs: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// TargetSourceLangs returns the lang of every target source by id.
+func TargetSourceLangs(ctx context.Context) (map[int]string, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.TargetSourceLangs)
+	if err != nil {
+		return nil, fmt.Errorf("TargetSourceLangs: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[int]string{}
+	for rows.Next() {
+		var id int
+		var lang string
+		if err := rows.Scan(&id, &lang); err != nil {
+			return nil, fmt.Errorf("TargetSourceLangs: scan error: %w", err)
+		}
+		out[id] = lang
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("TargetSourceLangs: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// TargetCategoryIDs returns the ids of the target feed categories.
+func TargetCategoryIDs(ctx context.Context) (map[int64]bool, error) {
+	rows, err := db.DB.QueryContext(ctx, queries.TargetCategoryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("TargetCategoryIDs: query error: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[int64]bool{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("TargetCategoryIDs: scan error: %w", err)
+		}
+		out[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("TargetCategoryIDs: rows iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// SyncFeeds upserts source feeds in one transaction. Feeds of sources that
+// do not exist in the target are skipped; unknown categories are dropped.
+func SyncFeeds(ctx context.Context, feeds []models.SyncFeed, langs map[int]string, categories map[int64]bool) (models.SyncCounts, error) {
+	var counts models.SyncCounts
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return counts, fmt.Errorf("SyncFeeds: begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, queries.SyncFeed)
+	if err != nil {
+		return counts, fmt.Errorf("SyncFeeds: prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, f := range feeds {
+		lang, ok := langs[f.SourceID]
+		if !ok {
+			counts.Skipped++
+			continue
+		}
+		category := f.CategoryID
+		if category.Valid && !categories[category.Int64] {
+			category = sql.NullInt64{}
+		}
+		_, masked := utils.TokenizeTitle(f.Title)
+
+		var inserted bool
+		err := stmt.QueryRowContext(ctx,
+			f.Title,               // $1
+			f.Link,                // $2
+			f.SourceID,            // $3
+			lang,                  // $4
+			pq.Array(f.Words),     // $5
+			pq.Array(masked),      // $6
+			f.Published,           // $7
+			dateParam(f.FeedDate), // $8
+			category,              // $9
+			f.Created,             // $10
+			f.Updated,             // $11
+			utils.TSConfig(lang),  // $12
+		).Scan(&inserted)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			counts.Skipped++
+		case err != nil:
+			return counts, fmt.Errorf("SyncFeeds: upsert error: %w", err)
+		case inserted:
+			counts.Inserted++
+		default:
+			counts.Updated++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return counts, fmt.Errorf("SyncFeeds: commit error: %w", err)
+	}
+	return counts, nil
+}
+
+// SyncFeedSentiments upserts source sentiments in one transaction, remapping
+// their feed through feedIDs (see TargetFeedIDs). Sentiments without a target
+// feed are skipped.
+func SyncFeedSentiments(ctx context.Context, rows []models.SyncFeedSentiment, feedIDs map[string]int64) (models.SyncCounts, error) {
+	var counts models.SyncCounts
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return counts, fmt.Errorf("SyncFeedSentiments: begin error: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, queries.SyncFeedSentiment)
+	if err != nil {
+		return counts, fmt.Errorf("SyncFeedSentiments: prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, s := range rows {
+		feedID, ok := feedIDs[FeedKey(s.SourceID, s.Link, s.FeedDate)]
+		if !ok {
+			counts.Skipped++
+			continue
+		}
+
+		var inserted bool
+		err := stmt.QueryRowContext(ctx,
+			feedID,                     // $1
+			s.ModelID,                  // $2
+			s.Sentiments,               // $3
+			s.SentimentKey,             // $4
+			s.SentimentValue,           // $5
+			s.SentimentCompound,        // $6
+			dateParam(s.SentimentDate), // $7
+			s.Created,                  // $8
+			s.Updated,                  // $9
+		).Scan(&inserted)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			counts.Skipped++
+		case err != nil:
+			return counts, fmt.Errorf("SyncFeedSentiments: upsert error: %w", err)
+		case inserted:
+			counts.Inserted++
+		default:
+			counts.Updated++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return counts, fmt.Errorf("SyncFeedSentiments: commit error: %w", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"golang-restapi/db"
+	"golang-restapi/queries"
+
+	"github.com/lib/pq"
+)
+
+// UserRoles returns the roles stored for email; none when there is no such user.
+func UserRoles(ctx context.Context, email string) ([]string, error) {
+	var roles []string
+	err := db.DB.QueryRowContext(ctx, queries.GetUserRoles, email).Scan(pq.Array(&roles))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("UserRoles: query error: %w", err)
+	}
+	return roles, nil
+}
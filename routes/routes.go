@@ -1,19 +1,23 @@
 package routes
 
 import (
-	"golang-restapi/handlers"
+	"net/http"
 	"time"
 
-	//"golang-restapi/middlewares"
-
 	"golang-restapi/config"
+	"golang-restapi/handlers"
+	"golang-restapi/metrics"
+	"golang-restapi/middlewares"
+	"golang-restapi/models"
+	"golang-restapi/utils"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-// SetupRoutes # Function to handle the API routes
-func SetupRoutes(r *gin.Engine, cfg config.Config) {
+// SetupRoutes # Function to handle the API routes. A nil limiter disables
+// rate limiting.
+func SetupRoutes(r *gin.Engine, cfg config.Config, auth *middlewares.Authenticator, limiter *middlewares.RateLimiter) {
 	r.Use(cors.New(cors.Config{
 		AllowOrigins: []string{
 			"http://localhost:5000",
@@ -24,24 +28,92 @@ func SetupRoutes(r *gin.Engine, cfg config.Config) {
 			"https://pow.palzoltan.net",
 			"https://devpow.palzoltan.net",
 		},
-		AllowMethods:     []string{"GET"}, // "PUT", "DELETE", "PATCH", "POST",  "OPTIONS"
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"}, // "PATCH", "OPTIONS"
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", middlewares.APIKeyHeader, utils.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", "ETag", utils.RequestIDHeader, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
+	// public routes, no token required
+	public := r.Group("/")
+	public.GET("/ping", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "pong"})
+	})
+	public.GET("/healthz", handlers.Healthz)
+	public.GET("/readyz", handlers.Readyz(cfg.PartitionsEnabled))
+	public.GET("/version", handlers.Version)
+
+	// everything below needs a valid bearer token or API key; API keys
+	// also need the scope of the group, /admin needs the admin role.
+	// Requests are limited by IP before authentication, so failed attempts
+	// count, then by caller.
 	protected := r.Group("/")
-	//protected.Use(middlewares.Authentication())
-
-	protected.GET("/pow/feeds", handlers.GetFeeds)
-	protected.GET("/pow/most_common_words", handlers.MostCommonWordsHandler)
-	protected.GET("/pow/get_sentiment_grouped", handlers.GetSentimentGrouped)
-	protected.GET("/pow/count_sentiments", handlers.CountSentiments)
-	protected.GET("/pow/top_feeds", handlers.TopFeeds)
-	protected.GET("/pow/bias_detection", handlers.BiasDetection)
-	protected.GET("/pow/correlation_between_sources_avg_compound", handlers.CorrelationBetweenSourcesAvgCompound)
-	protected.GET("/pow/word_co_occurences", handlers.WordCoOccurrences)
-	protected.GET("/pow/phrase_frequency_trends", handlers.PhraseFrequencyTrends)
-	protected.GET("/pow/overall_statistics", handlers.OverallStatistics)
+	protected.Use(limiter.LimitIP(), middlewares.Authentication(auth), limiter.Limit(middlewares.RouteCosts))
+
+	cache := middlewares.DefaultResponseCache.Cache()
+	feeds := protected.Group("/pow", middlewares.RequireScope(models.ScopeReadFeeds))
+	analytics := protected.Group("/pow", middlewares.RequireScope(models.ScopeReadAnalytics), cache)
+	admin := protected.Group("/admin",
+		middlewares.RequireScope(models.ScopeAdmin),
+		middlewares.RequireRole(models.RoleAdmin),
+	)
+
+	// Prometheus scrapes with a read:metrics API key; users need the admin role
+	if cfg.MetricsEnabled {
+		protected.GET("/metrics",
+			middlewares.RequireScopeOrRole(models.ScopeReadMetrics, models.RoleAdmin),
+			gin.WrapH(metrics.Handler()))
+	}
+
+	feeds.GET("/feeds", cache, handlers.GetFeeds)
+	feeds.GET("/google_news", handlers.GoogleNews)
+
+	analytics.GET("/most_common_words", handlers.MostCommonWordsHandler)
+	analytics.GET("/get_sentiment_grouped", handlers.GetSentimentGrouped)
+	analytics.GET("/count_sentiments", handlers.CountSentiments)
+	analytics.GET("/top_feeds", handlers.TopFeeds)
+	analytics.GET("/bias_detection", handlers.BiasDetection)
+	analytics.GET("/correlation_between_sources_avg_compound", handlers.CorrelationBetweenSourcesAvgCompound)
+	analytics.GET("/word_co_occurences", handlers.WordCoOccurrences)
+	analytics.GET("/phrase_frequency_trends", handlers.PhraseFrequencyTrends)
+	analytics.GET("/overall_statistics", handlers.OverallStatistics)
+	analytics.GET("/entities", handlers.Entities)
+	analytics.GET("/entity_timeline", handlers.EntityTimeline)
+
+	// entity alias dictionary
+	admin.GET("/entities", handlers.ListEntities)
+	admin.POST("/entities", handlers.CreateEntity)
+	admin.GET("/entities/:id", handlers.GetEntity)
+	admin.PUT("/entities/:id", handlers.UpdateEntity)
+	admin.DELETE("/entities/:id", handlers.DeleteEntity)
+	admin.POST("/entities/:id/aliases", handlers.AddEntityAlias)
+	admin.DELETE("/entities/:id/aliases/:alias_id", handlers.DeleteEntityAlias)
+
+	// stopwords and stop-phrases
+	admin.GET("/stopwords", handlers.ListStopwords)
+	admin.POST("/stopwords", handlers.AddStopwords)
+	admin.DELETE("/stopwords", handlers.DeleteStopword)
+
+	// monthly partitions of feeds and feed_sentiments
+	admin.GET("/partitions", handlers.PartitionCoverage(cfg.PartitionMonthsAhead))
+
+	// sentiment backfill jobs
+	admin.GET("/backfill", handlers.ListBackfillJobs)
+	admin.POST("/backfill", handlers.CreateBackfillJob)
+	admin.GET("/backfill/:id", handlers.GetBackfillJob)
+	admin.POST("/backfill/:id/pause", handlers.PauseBackfillJob)
+	admin.POST("/backfill/:id/resume", handlers.ResumeBackfillJob)
+
+	// API keys for scripts
+	admin.GET("/api_keys", handlers.ListAPIKeys)
+	admin.POST("/api_keys", handlers.CreateAPIKey)
+	admin.DELETE("/api_keys/:id", handlers.RevokeAPIKey)
+
+	// cached analytics responses
+	admin.GET("/response_cache", handlers.ResponseCacheStats)
+	admin.DELETE("/response_cache", handlers.PurgeResponseCache)
+
+	// sentiment cache
+	admin.GET("/sentiment_cache", handlers.SentimentCacheStats)
 }
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-restapi/config"
+	"golang-restapi/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// publicRoutes are served without credentials.
+var publicRoutes = map[string]bool{
+	"GET /ping":    true,
+	"GET /healthz": true,
+	"GET /readyz":  true,
+	"GET /version": true,
+}
+
+var pathParam = regexp.MustCompile(`[:*][^/]+`)
+
+// TestRoutesRequireAuthentication checks that every route but the public
+// ones, every write and admin route in particular, refuses a request
+// without a token or API key.
+func TestRoutesRequireAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Config{JWTSecret: "secret", AuthClockSkew: time.Minute, MetricsEnabled: true}
+	r := gin.New()
+	SetupRoutes(r, cfg, middlewares.NewAuthenticator(cfg, nil), nil)
+
+	var writes, admin int
+	for _, route := range r.Routes() {
+		name := route.Method + " " + route.Path
+		if publicRoutes[name] {
+			continue
+		}
+		if route.Method != http.MethodGet {
+			writes++
+		}
+		if strings.HasPrefix(route.Path, "/admin/") {
+			admin++
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(route.Method, pathParam.ReplaceAllString(route.Path, "1"), nil)
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s without credentials: %d, want 401", name, w.Code)
+		}
+	}
+	if writes == 0 || admin == 0 {
+		t.Fatalf("found %d write and %d admin routes, the walk is broken", writes, admin)
+	}
+}
+
+// TestEntityDictionaryWrites checks the write routes of the entity alias
+// dictionary by name, so they cannot drift out of the admin group unnoticed:
+// they refuse requests without credentials, and CORS lets only the known
+// origins send them.
+func TestEntityDictionaryWrites(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Config{JWTSecret: "secret", AuthClockSkew: time.Minute}
+	r := gin.New()
+	SetupRoutes(r, cfg, middlewares.NewAuthenticator(cfg, nil), nil)
+
+	writes := [][2]string{
+		{http.MethodPost, "/admin/entities"},
+		{http.MethodPut, "/admin/entities/1"},
+		{http.MethodDelete, "/admin/entities/1"},
+		{http.MethodPost, "/admin/entities/1/aliases"},
+		{http.MethodDelete, "/admin/entities/1/aliases/2"},
+	}
+	for _, route := range writes {
+		method, path := route[0], route[1]
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Origin", "https://pow.palzoltan.net")
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s %s without credentials: %d, want 401", method, path, w.Code)
+		}
+
+		for origin, allowed := range map[string]bool{"https://pow.palzoltan.net": true, "https://evil.example": false} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodOptions, path, nil)
+			req.Header.Set("Origin", origin)
+			req.Header.Set("Access-Control-Request-Method", method)
+			r.ServeHTTP(w, req)
+			if got := w.Header().Get("Access-Control-Allow-Origin") == origin; got != allowed {
+				t.Errorf("preflight of %s %s from %s allowed %v, want %v", method, path, origin, got, allowed)
+			}
+		}
+	}
+}
+
+// TestMetricsServedToAdmins checks that /metrics, refused above without
+// credentials, is served to a token with the admin role.
+func TestMetricsServedToAdmins(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Config{JWTSecret: "secret", AuthClockSkew: time.Minute, AuthRolesClaim: "roles", MetricsEnabled: true}
+	r := gin.New()
+	SetupRoutes(r, cfg, middlewares.NewAuthenticator(cfg, nil), nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer "+token(t, "admin"))
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("/metrics as admin: %d, want 200", w.Code)
+	}
+}
+
+func token(t *testing.T, role string) string {
+	t.Helper()
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":   "pow",
+		"email": role + "@example.com",
+		"roles": []string{role},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
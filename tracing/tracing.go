@@ -0,0 +1,68 @@
+// Package tracing sets up the OpenTelemetry tracer provider of the API.
+// Spans are started by middlewares.Tracing (HTTP requests), by the SQL
+// connector of the metrics package (statements) and by the otelgrpc stats
+// handlers (gRPC calls).
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// ServiceName is the service.name of the spans, unless OTEL_SERVICE_NAME is set.
+const ServiceName = "golang-restapi"
+
+// Init installs the global tracer provider and the W3C trace context
+// propagator. exporter is "otlp" (gRPC, to endpoint or the
+// OTEL_EXPORTER_OTLP_* settings), "stdout" for local debugging, or "none".
+// The returned function flushes pending spans; call it on shutdown.
+func Init(ctx context.Context, exporter, endpoint string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exp sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		var opts []otlptracegrpc.Option
+		if endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(endpoint))
+		}
+		exp, err = otlptracegrpc.New(ctx, opts...)
+	case "stdout":
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("tracing: unknown exporter %q (otlp, stdout or none)", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %s exporter: %w", exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: resource: %w", err)
+	}
+
+	// the sampler follows OTEL_TRACES_SAMPLER, parent based always-on by default
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := NewCircuitBreaker(3, time.Hour)
+	b.Failure()
+	b.Failure()
+	b.Success() // resets the count
+	b.Failure()
+	b.Failure()
+	if b.State() != CircuitClosed || !b.Allow() {
+		t.Fatalf("state = %s after 2 consecutive failures, want closed", b.State())
+	}
+	b.Failure()
+	if b.State() != CircuitOpen || b.Allow() {
+		t.Errorf("state = %s after 3 consecutive failures, want open", b.State())
+	}
+}
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	b := NewCircuitBreaker(1, 10*time.Millisecond)
+	b.Failure()
+	if b.Allow() {
+		t.Fatal("call let through during the cooldown")
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if !b.Allow() || b.State() != CircuitHalfOpen {
+		t.Fatalf("no probe after the cooldown, state = %s", b.State())
+	}
+	if b.Allow() {
+		t.Error("second call let through while probing")
+	}
+
+	// a failed probe reopens at once
+	b.Failure()
+	if b.State() != CircuitOpen || b.Allow() {
+		t.Errorf("state = %s after a failed probe, want open", b.State())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	b.Allow()
+	b.Success()
+	if b.State() != CircuitClosed || !b.Allow() || !b.Allow() {
+		t.Errorf("state = %s after a successful probe, want closed", b.State())
+	}
+}
+
+func TestCircuitBreakerRelease(t *testing.T) {
+	b := NewCircuitBreaker(1, 0)
+	b.Failure()
+	if !b.Allow() {
+		t.Fatal("no probe")
+	}
+	b.Release()
+	if b.State() != CircuitHalfOpen {
+		t.Errorf("state = %s after Release, want half-open", b.State())
+	}
+	if !b.Allow() {
+		t.Error("no new probe after a released one")
+	}
+}
+
+func TestCircuitBreakerZeroThreshold(t *testing.T) {
+	b := NewCircuitBreaker(0, time.Hour)
+	for range 10 {
+		b.Failure()
+	}
+	if b.State() != CircuitClosed {
+		t.Errorf("state = %s, want a zero threshold to never open", b.State())
+	}
+}
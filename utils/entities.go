@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EntityDictionary maps known surface forms (aliases and stems) of named
+// entities to their canonical name.
+type EntityDictionary struct {
+	aliases map[string]string // lowercased alias -> canonical name
+	stems   []entityStem      // sorted by length, longest first
+}
+
+type entityStem struct {
+	stem      string
+	tokens    int
+	canonical string
+}
+
+// NewEntityDictionary builds a dictionary from canonical name -> aliases.
+// An alias ending in "*" is a stem and matches any name starting with it, so
+// inflected forms ("Orbánt", "Orbánnak") resolve to the same canonical entity.
+func NewEntityDictionary(entries map[string][]string) *EntityDictionary {
+	d := &EntityDictionary{aliases: make(map[string]string)}
+	for canonical, aliases := range entries {
+		d.Add(canonical, aliases...)
+	}
+	return d
+}
+
+// Add registers a canonical name together with its aliases.
+func (d *EntityDictionary) Add(canonical string, aliases ...string) {
+	canonical = strings.TrimSpace(canonical)
+	if canonical == "" {
+		return
+	}
+	for _, a := range append([]string{canonical}, aliases...) {
+		a = strings.ToLower(strings.TrimSpace(a))
+		if stem, isStem := strings.CutSuffix(a, "*"); isStem {
+			if stem != "" {
+				d.stems = append(d.stems, entityStem{stem: stem, tokens: len(strings.Fields(stem)), canonical: canonical})
+			}
+			continue
+		}
+		if a != "" {
+			d.aliases[a] = canonical
+		}
+	}
+	sort.SliceStable(d.stems, func(i, j int) bool {
+		return len(d.stems[i].stem) > len(d.stems[j].stem)
+	})
+}
+
+// Canonical resolves a name to its canonical form. A stem only matches names
+// with the same number of tokens. ok=false if the name is not in the dictionary.
+func (d *EntityDictionary) Canonical(name string) (canonical string, ok bool) {
+	if d == nil {
+		return "", false
+	}
+	lower := strings.ToLower(name)
+	if c, found := d.aliases[lower]; found {
+		return c, true
+	}
+	tokens := len(strings.Fields(lower))
+	for _, s := range d.stems {
+		if s.tokens == tokens && strings.HasPrefix(lower, s.stem) {
+			return s.canonical, true
+		}
+	}
+	return "", false
+}
+
+// ExtractEntities returns the canonical names found in a title.
+// A name is a run of capitalised tokens. Known names are matched first
+// (longest match wins); the remaining runs of two or more tokens are kept as-is,
+// minus leading stopwords.
+func ExtractEntities(title string, dict *EntityDictionary, stop *StopwordSet) []string {
+	var out, run []string
+	seen := map[string]struct{}{}
+
+	emit := func(name string) {
+		if _, dup := seen[name]; !dup {
+			seen[name] = struct{}{}
+			out = append(out, name)
+		}
+	}
+
+	// emitUnknown keeps multi-token names, minus leading capitalised stopwords ("A", "Az", "Ez")
+	emitUnknown := func(toks []string) {
+		for len(toks) > 0 && stop.IsStopword(toks[0]) {
+			toks = toks[1:]
+		}
+		if len(toks) >= 2 {
+			emit(strings.Join(toks, " "))
+		}
+	}
+
+	flush := func() {
+		unknownFrom := 0
+		for i := 0; i < len(run); {
+			matched := 0
+			for j := len(run); j > i; j-- {
+				if c, ok := dict.Canonical(strings.Join(run[i:j], " ")); ok {
+					emitUnknown(run[unknownFrom:i])
+					emit(c)
+					matched = j - i
+					break
+				}
+			}
+			if matched == 0 {
+				i++
+				continue
+			}
+			i += matched
+			unknownFrom = i
+		}
+		emitUnknown(run[unknownFrom:])
+		run = run[:0]
+	}
+
+	for _, raw := range strings.Fields(title) {
+		tok := strings.TrimFunc(raw, isEntityTrim)
+		if tok != "" && isCapitalised(tok) {
+			run = append(run, tok)
+		} else {
+			flush()
+		}
+
+		// trailing punctuation closes the run
+		last, _ := utf8.DecodeLastRuneInString(raw)
+		switch last {
+		case ':', '.', '!', '?', ',', ';', '"', '”':
+			flush()
+		}
+	}
+	flush()
+	return out
+}
+
+func isCapitalised(tok string) bool {
+	r, _ := utf8.DecodeRuneInString(tok)
+	return unicode.IsUpper(r)
+}
+
+func isEntityTrim(r rune) bool {
+	return unicode.IsPunct(r) && r != '-'
+}
@@ -0,0 +1,257 @@
+package utils
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// FeedItem is a normalised feed entry of any supported format.
+type FeedItem struct {
+	Title       string
+	Link        string
+	GUID        string
+	Description string
+	Categories  []string
+	Source      string
+	Published   time.Time // zero when the date did not parse
+	PubDate     string    // the date as written in the feed
+}
+
+// Feed formats detected by ParseFeed.
+const (
+	FormatRSS      = "rss"
+	FormatAtom     = "atom"
+	FormatJSONFeed = "jsonfeed"
+)
+
+// ErrUnknownFeedFormat is returned for documents that are neither RSS, Atom nor JSON Feed.
+var ErrUnknownFeedFormat = errors.New("unknown feed format")
+
+// DetectFeedFormat sniffs the format from the document root.
+func DetectFeedFormat(body []byte) (string, error) {
+	body = bytes.TrimPrefix(bytes.TrimSpace(body), []byte("\xef\xbb\xbf")) // UTF-8 BOM
+	if len(body) == 0 {
+		return "", ErrUnknownFeedFormat
+	}
+	if body[0] == '{' {
+		return FormatJSONFeed, nil
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.CharsetReader = charset.NewReaderLabel
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", ErrUnknownFeedFormat
+		}
+		if el, ok := tok.(xml.StartElement); ok {
+			switch strings.ToLower(el.Name.Local) {
+			case "rss":
+				return FormatRSS, nil
+			case "feed":
+				return FormatAtom, nil
+			default:
+				return "", ErrUnknownFeedFormat
+			}
+		}
+	}
+}
+
+// ParseFeed parses an RSS 2.0, Atom 1.0 or JSON Feed 1.x document.
+func ParseFeed(body []byte) ([]FeedItem, error) {
+	format, err := DetectFeedFormat(body)
+	if err != nil {
+		return nil, fmt.Errorf("ParseFeed: %w", err)
+	}
+	switch format {
+	case FormatAtom:
+		return ParseAtom(body)
+	case FormatJSONFeed:
+		return ParseJSONFeed(body)
+	default:
+		return ParseRSS(body)
+	}
+}
+
+// Atom struct for parsing Atom 1.0 feeds
+type Atom struct {
+	Entries []struct {
+		Title string `xml:"title"`
+		ID    string `xml:"id"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Published  string `xml:"published"`
+		Updated    string `xml:"updated"`
+		Summary    string `xml:"summary"`
+		Content    string `xml:"content"`
+		Categories []struct {
+			Term  string `xml:"term,attr"`
+			Label string `xml:"label,attr"`
+		} `xml:"category"`
+		Source struct {
+			Title string `xml:"title"`
+		} `xml:"source"`
+	} `xml:"entry"`
+}
+
+// ParseAtom parses an Atom 1.0 document.
+func ParseAtom(body []byte) ([]FeedItem, error) {
+	var atom Atom
+	if err := decodeXML(body, &atom); err != nil {
+		return nil, fmt.Errorf("ParseAtom: parse error: %w", err)
+	}
+
+	items := make([]FeedItem, 0, len(atom.Entries))
+	for _, e := range atom.Entries {
+		item := FeedItem{
+			Title:       strings.TrimSpace(e.Title),
+			GUID:        strings.TrimSpace(e.ID),
+			Description: strings.TrimSpace(e.Summary),
+			Source:      strings.TrimSpace(e.Source.Title),
+			PubDate:     strings.TrimSpace(cmp.Or(e.Published, e.Updated)),
+		}
+		if item.Description == "" {
+			item.Description = strings.TrimSpace(e.Content)
+		}
+		// rel="alternate" (the default) is the article itself
+		for _, l := range e.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				item.Link = strings.TrimSpace(l.Href)
+				break
+			}
+		}
+		for _, c := range e.Categories {
+			if c.Label != "" {
+				item.Categories = append(item.Categories, strings.TrimSpace(c.Label))
+			} else if c.Term != "" {
+				item.Categories = append(item.Categories, strings.TrimSpace(c.Term))
+			}
+		}
+		if t, ok := ParseFeedDate(e.Published); ok {
+			item.Published = t
+		} else if t, ok := ParseFeedDate(e.Updated); ok {
+			item.Published = t
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// JSONFeed struct for parsing JSON Feed 1.0/1.1
+type JSONFeed struct {
+	Version string `json:"version"`
+	Title   string `json:"title"`
+	Items   []struct {
+		ID            json.RawMessage `json:"id"` // a string by spec, numbers in the wild
+		URL           string          `json:"url"`
+		ExternalURL   string          `json:"external_url"`
+		Title         string          `json:"title"`
+		Summary       string          `json:"summary"`
+		ContentText   string          `json:"content_text"`
+		DatePublished string          `json:"date_published"`
+		DateModified  string          `json:"date_modified"`
+		Tags          []string        `json:"tags"`
+	} `json:"items"`
+}
+
+// ParseJSONFeed parses a JSON Feed 1.x document.
+func ParseJSONFeed(body []byte) ([]FeedItem, error) {
+	var feed JSONFeed
+	if err := json.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("ParseJSONFeed: parse error: %w", err)
+	}
+	if !strings.HasPrefix(feed.Version, "https://jsonfeed.org/version/") {
+		return nil, fmt.Errorf("ParseJSONFeed: %w: version %q", ErrUnknownFeedFormat, feed.Version)
+	}
+
+	items := make([]FeedItem, 0, len(feed.Items))
+	for _, it := range feed.Items {
+		item := FeedItem{
+			Title:       strings.TrimSpace(it.Title),
+			Link:        strings.TrimSpace(it.URL),
+			GUID:        strings.Trim(strings.TrimSpace(string(it.ID)), `"`),
+			Description: strings.TrimSpace(it.Summary),
+			Categories:  it.Tags,
+			PubDate:     strings.TrimSpace(cmp.Or(it.DatePublished, it.DateModified)),
+		}
+		if item.Link == "" {
+			item.Link = strings.TrimSpace(it.ExternalURL)
+		}
+		if item.Description == "" {
+			item.Description = strings.TrimSpace(it.ContentText)
+		}
+		if t, ok := ParseFeedDate(it.DatePublished); ok {
+			item.Published = t
+		} else if t, ok := ParseFeedDate(it.DateModified); ok {
+			item.Published = t
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// feedZoneOffsets maps the zone abbreviations used by (Hungarian) feeds to
+// numeric offsets. time.Parse would read unknown abbreviations as UTC.
+var feedZoneOffsets = map[string]string{
+	"UT": "+0000", "UTC": "+0000", "GMT": "+0000", "Z": "+0000",
+	"CET": "+0100", "MET": "+0100", "KEI": "+0100", // közép-európai idő
+	"CEST": "+0200", "MEST": "+0200", "KENYI": "+0200", // közép-európai nyári idő
+	"EET": "+0200", "EEST": "+0300",
+	"EST": "-0500", "EDT": "-0400", "PST": "-0800", "PDT": "-0700",
+}
+
+var trailingZone = regexp.MustCompile(`\s([A-Za-z]{1,5})$`)
+
+// feedDateLayouts are tried in order after zone abbreviations are replaced.
+var feedDateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04 -0700",
+	"Monday, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 January 2006 15:04:05 -0700",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseFeedDate parses the dates of RSS (RFC 822/1123, also with CET/CEST
+// and Hungarian zone names), Atom and JSON Feed (RFC 3339).
+// ok=false if no known layout matches.
+func ParseFeedDate(s string) (t time.Time, ok bool) {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return time.Time{}, false
+	}
+	if m := trailingZone.FindStringSubmatch(s); m != nil {
+		if off, known := feedZoneOffsets[strings.ToUpper(m[1])]; known {
+			s = s[:len(s)-len(m[1])] + off
+		}
+	}
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func decodeXML(body []byte, v any) error {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.CharsetReader = charset.NewReaderLabel
+	return dec.Decode(v)
+}
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDetectFeedFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string // "" for ErrUnknownFeedFormat
+	}{
+		{"rss", `<?xml version="1.0" encoding="UTF-8"?><rss version="2.0"><channel/></rss>`, FormatRSS},
+		{"rss with BOM", "\xef\xbb\xbf<rss version=\"2.0\"/>", FormatRSS},
+		{"rss uppercase", `<RSS/>`, FormatRSS},
+		{"rss latin-2", `<?xml version="1.0" encoding="ISO-8859-2"?><rss/>`, FormatRSS},
+		{"atom", `<?xml version="1.0"?><!-- generator --><feed xmlns="http://www.w3.org/2005/Atom"/>`, FormatAtom},
+		{"json feed", `{"version": "https://jsonfeed.org/version/1.1"}`, FormatJSONFeed},
+		{"json feed after whitespace", "\n  {}", FormatJSONFeed},
+		{"rdf", `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"/>`, ""},
+		{"html", `<!DOCTYPE html><html><body/></html>`, ""},
+		{"json array", `[{"title": "x"}]`, ""},
+		{"text", `not a feed`, ""},
+		{"empty", " \n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectFeedFormat([]byte(tt.body))
+			if tt.want == "" {
+				if !errors.Is(err, ErrUnknownFeedFormat) {
+					t.Errorf("= %q, %v, want ErrUnknownFeedFormat", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("= %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFeedUnknownFormat(t *testing.T) {
+	for _, body := range []string{`<html/>`, `<rdf:RDF/>`, ``} {
+		if items, err := ParseFeed([]byte(body)); !errors.Is(err, ErrUnknownFeedFormat) {
+			t.Errorf("ParseFeed(%q) = %d items, %v, want ErrUnknownFeedFormat", body, len(items), err)
+		}
+	}
+}
+
+func TestParseAtom(t *testing.T) {
+	body := `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Telex</title>
+  <entry>
+    <title> Első cikk </title>
+    <id>urn:telex:1</id>
+    <link rel="self" href="https://telex.hu/api/1"/>
+    <link href="https://telex.hu/1"/>
+    <published>2025-10-14T08:30:00+02:00</published>
+    <updated>2025-10-15T10:00:00+02:00</updated>
+    <summary>Összefoglaló</summary>
+    <content>Tartalom</content>
+    <category term="belfold" label="Belföld"/>
+    <category term="politika"/>
+    <source><title>MTI</title></source>
+  </entry>
+  <entry>
+    <title>Második cikk</title>
+    <link rel="alternate" href="https://telex.hu/2"/>
+    <updated>2025-10-15T10:00:00Z</updated>
+    <content>Csak tartalom</content>
+  </entry>
+  <entry>
+    <title>Dátum nélkül</title>
+    <published>tegnap</published>
+  </entry>
+</feed>`
+	items, err := ParseFeed([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+
+	first := items[0]
+	if first.Title != "Első cikk" || first.Link != "https://telex.hu/1" || first.GUID != "urn:telex:1" ||
+		first.Description != "Összefoglaló" || first.Source != "MTI" {
+		t.Errorf("item 0 = %+v", first)
+	}
+	if !slices.Equal(first.Categories, []string{"Belföld", "politika"}) {
+		t.Errorf("item 0 categories = %q", first.Categories)
+	}
+	if want := time.Date(2025, 10, 14, 6, 30, 0, 0, time.UTC); !first.Published.Equal(want) || first.PubDate != "2025-10-14T08:30:00+02:00" {
+		t.Errorf("item 0 published %s (%q), want %s", first.Published, first.PubDate, want)
+	}
+
+	second := items[1]
+	if second.Link != "https://telex.hu/2" || second.Description != "Csak tartalom" {
+		t.Errorf("item 1 = %+v, want the alternate link and the content", second)
+	}
+	if want := time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC); !second.Published.Equal(want) {
+		t.Errorf("item 1 published %s, want the updated date %s", second.Published, want)
+	}
+
+	if third := items[2]; !third.Published.IsZero() || third.PubDate != "tegnap" {
+		t.Errorf("item 2 published %s (%q), want zero and the raw date", third.Published, third.PubDate)
+	}
+
+	if _, err := ParseAtom([]byte(`<feed><entry>`)); err == nil {
+		t.Error("ParseAtom accepted a truncated document")
+	}
+}
+
+func TestParseJSONFeed(t *testing.T) {
+	body := `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "444",
+  "items": [
+    {"id": "abc", "url": "https://444.hu/1", "title": " Első ", "summary": "Összefoglaló",
+     "content_text": "Tartalom", "date_published": "2025-10-14T08:30:00+02:00", "tags": ["belföld"]},
+    {"id": 42, "external_url": "https://mti.hu/2", "title": "Második",
+     "content_text": "Csak tartalom", "date_modified": "2025-10-15T10:00:00Z"}
+  ]
+}`
+	items, err := ParseFeed([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+
+	first := items[0]
+	if first.GUID != "abc" || first.Link != "https://444.hu/1" || first.Title != "Első" ||
+		first.Description != "Összefoglaló" || !slices.Equal(first.Categories, []string{"belföld"}) {
+		t.Errorf("item 0 = %+v", first)
+	}
+	if want := time.Date(2025, 10, 14, 6, 30, 0, 0, time.UTC); !first.Published.Equal(want) {
+		t.Errorf("item 0 published %s, want %s", first.Published, want)
+	}
+
+	second := items[1]
+	if second.GUID != "42" || second.Link != "https://mti.hu/2" || second.Description != "Csak tartalom" {
+		t.Errorf("item 1 = %+v, want the numeric id, the external url and the content", second)
+	}
+	if want := time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC); !second.Published.Equal(want) || second.PubDate != "2025-10-15T10:00:00Z" {
+		t.Errorf("item 1 published %s (%q), want the modified date %s", second.Published, second.PubDate, want)
+	}
+
+	for _, bad := range []string{
+		`{"version": "1.1", "items": []}`,
+		`{"items": []}`,
+	} {
+		if _, err := ParseJSONFeed([]byte(bad)); !errors.Is(err, ErrUnknownFeedFormat) {
+			t.Errorf("ParseJSONFeed(%s) err = %v, want ErrUnknownFeedFormat", bad, err)
+		}
+	}
+	if _, err := ParseJSONFeed([]byte(`{"version": `)); err == nil {
+		t.Error("ParseJSONFeed accepted truncated JSON")
+	}
+}
+
+func TestParseFeedDate(t *testing.T) {
+	at := func(hour, min int) time.Time { return time.Date(2025, 10, 14, hour, min, 0, 0, time.UTC) }
+	tests := []struct {
+		in   string
+		want time.Time // zero when it must not parse
+	}{
+		// one per layout
+		{"2025-10-14T08:30:00.5+02:00", at(6, 30).Add(500 * time.Millisecond)},
+		{"2025-10-14T08:30:00+02:00", at(6, 30)},
+		{"2025-10-14T08:30:00Z", at(8, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 +0200", at(6, 30)},
+		{"Tue, 14 Oct 2025 08:30 +0200", at(6, 30)},
+		{"14 Oct 2025 08:30:00 +0200", at(6, 30)},
+		{"14 Oct 2025 08:30 +0200", at(6, 30)},
+		{"Tuesday, 14 Oct 2025 08:30:00 +0200", at(6, 30)},
+		{"Tue, 14 October 2025 08:30:00 +0200", at(6, 30)},
+		{"2025-10-14 08:30:00 +0200", at(6, 30)},
+		{"2025-10-14T08:30:00", at(8, 30)},
+		{"2025-10-14 08:30:00", at(8, 30)},
+		{"2025-10-14", at(0, 0)},
+
+		// zone names
+		{"Tue, 14 Oct 2025 08:30:00 GMT", at(8, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 UT", at(8, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 UTC", at(8, 30)},
+		{"2025-10-14 08:30:00 Z", at(8, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 CEST", at(6, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 MEST", at(6, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 KENYI", at(6, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 kenyi", at(6, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 CET", at(7, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 MET", at(7, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 KEI", at(7, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 EET", at(6, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 EEST", at(5, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 EST", at(13, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 EDT", at(12, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 PST", at(16, 30)},
+		{"Tue, 14 Oct 2025 08:30:00 PDT", at(15, 30)},
+		{"  Tue,  14 Oct 2025\n08:30 KEI ", at(7, 30)},
+
+		// not dates
+		{"", time.Time{}},
+		{"tegnap délután", time.Time{}},
+		{"Tue, 14 Oct 2025 08:30:00 XYZ", time.Time{}},
+		{"2025-13-01", time.Time{}},
+		{"14/10/2025", time.Time{}},
+	}
+	for _, tt := range tests {
+		got, ok := ParseFeedDate(tt.in)
+		if ok != !tt.want.IsZero() || !got.Equal(tt.want) {
+			t.Errorf("ParseFeedDate(%q) = %s, %v, want %s", tt.in, got, ok, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@
 package utils
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -106,3 +107,69 @@ func SanitizeTSWord(w string) string {
 	}, w)
 	return strings.TrimSpace(w)
 }
+
+// SearchTerm is a word or phrase to search for; Stem=true also matches
+// every form starting with it.
+type SearchTerm struct {
+	Text string
+	Stem bool
+}
+
+// BuildTermsTSQuery builds an OR-ed to_tsquery string from terms, e.g.
+// "orbán:* | (magyar <-> péter:*)". Phrases are joined with <->, stems
+// (or every term when prefix==true) get :* on their last token.
+// Returns ok=false if nothing usable remains after sanitizing.
+func BuildTermsTSQuery(terms []SearchTerm, prefix bool) (q string, ok bool) {
+	parts := make([]string, 0, len(terms))
+	for _, t := range terms {
+		toks := strings.Fields(SanitizeTSWord(t.Text))
+		if len(toks) == 0 {
+			continue
+		}
+		if t.Stem || prefix {
+			toks[len(toks)-1] += ":*"
+		}
+		part := strings.Join(toks, " <-> ")
+		if len(toks) > 1 {
+			part = "(" + part + ")"
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, " | "), true
+}
+
+// RegexPatterns turns terms into case-insensitive PostgreSQL regexes (~*).
+// With tokens==false a pattern matches the whole term inside a text on word
+// boundaries; with tokens==true every word of a phrase becomes a pattern
+// matching a single array element. Stems are left open on the right.
+func RegexPatterns(terms []SearchTerm, tokens bool) []string {
+	var out []string
+	for _, t := range terms {
+		words := strings.Fields(t.Text)
+		if len(words) == 0 {
+			continue
+		}
+		for i := range words {
+			words[i] = regexp.QuoteMeta(words[i])
+		}
+		if tokens {
+			for i, w := range words {
+				if t.Stem && i == len(words)-1 {
+					out = append(out, "^"+w)
+				} else {
+					out = append(out, "^"+w+"$")
+				}
+			}
+			continue
+		}
+		p := `\m` + strings.Join(words, `\s+`)
+		if !t.Stem {
+			p += `\M`
+		}
+		out = append(out, p)
+	}
+	return out
+}
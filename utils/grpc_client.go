@@ -1,32 +1,169 @@
 package utils
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
 	"golang-restapi/sentimentpb"
 )
 
-var (
-	sentimentConn   *grpc.ClientConn
-	SentimentClient sentimentpb.SentimentServiceClient
-)
+// SentimentClientConfig configures the connection to the SentimentService.
+type SentimentClientConfig struct {
+	Addr             string
+	TLS              bool
+	TLSCAFile        string // optional CA bundle, the system pool otherwise
+	TLSServerName    string // optional override of the name in the certificate
+	Timeout          time.Duration
+	Retries          int           // extra attempts on codes.Unavailable
+	RetryBackoff     time.Duration // first retry delay, doubled on every attempt
+	BreakerThreshold int           // consecutive failures that open the breaker
+	BreakerCooldown  time.Duration // time before a probe call is let through
+}
+
+// SentimentClient is a sentimentpb.SentimentServiceClient with a per-call
+// deadline, retries on Unavailable and a circuit breaker.
+type SentimentClient struct {
+	cfg     SentimentClientConfig
+	conn    *grpc.ClientConn
+	client  sentimentpb.SentimentServiceClient
+	health  healthpb.HealthClient
+	breaker *CircuitBreaker
+}
 
-// InitSentimentClient connects to the gRPC sentiment service and stores the client globally.
-func InitSentimentClient(addr string) {
-	var err error
-	sentimentConn, err = grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewSentimentClient creates the client. The connection is established lazily
+// on the first call, so a down service does not stop the API from starting.
+func NewSentimentClient(cfg SentimentClientConfig) (*SentimentClient, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLS {
+		if cfg.TLSCAFile != "" {
+			var err error
+			if creds, err = credentials.NewClientTLSFromFile(cfg.TLSCAFile, cfg.TLSServerName); err != nil {
+				return nil, fmt.Errorf("NewSentimentClient: tls error: %w", err)
+			}
+		} else {
+			creds = credentials.NewClientTLSFromCert(nil, cfg.TLSServerName)
+		}
+	}
+
+	conn, err := grpc.NewClient(cfg.Addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(RequestIDClientInterceptor, MetricsClientInterceptor),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 	if err != nil {
-		log.Fatalf("failed to connect to sentiment server at %s: %v", addr, err)
+		return nil, fmt.Errorf("NewSentimentClient: %s: %w", cfg.Addr, err)
 	}
-	SentimentClient = sentimentpb.NewSentimentServiceClient(sentimentConn)
+	return &SentimentClient{
+		cfg:     cfg,
+		conn:    conn,
+		client:  sentimentpb.NewSentimentServiceClient(conn),
+		health:  healthpb.NewHealthClient(conn),
+		breaker: NewCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}, nil
+}
+
+// Close closes the connection (call on shutdown).
+func (c *SentimentClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *SentimentClient) Analyze(ctx context.Context, in *sentimentpb.AnalyzeRequest, opts ...grpc.CallOption) (*sentimentpb.AnalyzeResponse, error) {
+	var resp *sentimentpb.AnalyzeResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.client.Analyze(ctx, in, opts...)
+		return err
+	})
+	return resp, err
 }
 
-// CloseSentimentClient closes the gRPC connection (call on shutdown).
-func CloseSentimentClient() {
-	if sentimentConn != nil {
-		_ = sentimentConn.Close()
+func (c *SentimentClient) BatchAnalyze(ctx context.Context, in *sentimentpb.BatchAnalyzeRequest, opts ...grpc.CallOption) (*sentimentpb.BatchAnalyzeResponse, error) {
+	var resp *sentimentpb.BatchAnalyzeResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.client.BatchAnalyze(ctx, in, opts...)
+		return err
+	})
+	return resp, err
+}
+
+// call runs fn through the breaker, retrying Unavailable with jittered backoff.
+// Only Unavailable and DeadlineExceeded count as failures of the service; a
+// call ended by the caller's own context says nothing about it.
+func (c *SentimentClient) call(ctx context.Context, fn func(context.Context) error) error {
+	backoff := c.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if !c.breaker.Allow() {
+			return ErrCircuitOpen
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+		err := fn(callCtx)
+		cancel()
+
+		code := status.Code(err)
+		switch {
+		case err == nil:
+			c.breaker.Success()
+			return nil
+		case ctx.Err() != nil:
+			c.breaker.Release()
+			return err
+		case code == codes.Unavailable || code == codes.DeadlineExceeded:
+			c.breaker.Failure()
+		default:
+			c.breaker.Success() // the service answered
+			return err
+		}
+
+		if code != codes.Unavailable || attempt >= c.cfg.Retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff/2 + rand.N(backoff/2+1)):
+		}
+		backoff *= 2
 	}
 }
+
+// Check asks the standard gRPC health service whether the SentimentService
+// is serving. Servers without a health service are checked by the channel.
+func (c *SentimentClient) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.health.Check(ctx, &healthpb.HealthCheckRequest{Service: sentimentpb.SentimentService_ServiceDesc.ServiceName})
+	if status.Code(err) == codes.Unimplemented {
+		resp, err = c.health.Check(ctx, &healthpb.HealthCheckRequest{})
+	}
+	if status.Code(err) == codes.Unimplemented {
+		return nil // no health service; the call itself went through
+	}
+	if err != nil {
+		return fmt.Errorf("sentiment health check: %w", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("sentiment health check: %s", resp.GetStatus())
+	}
+	return nil
+}
+
+// Available reports whether calls are let through (the breaker is not open).
+func (c *SentimentClient) Available() bool {
+	return c.breaker.State() != CircuitOpen
+}
+
+// BreakerState returns the state of the circuit breaker.
+func (c *SentimentClient) BreakerState() string {
+	return c.breaker.State()
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testSentimentClient(retries, threshold int) *SentimentClient {
+	return &SentimentClient{
+		cfg:     SentimentClientConfig{Timeout: time.Second, Retries: retries, RetryBackoff: time.Millisecond},
+		breaker: NewCircuitBreaker(threshold, time.Hour),
+	}
+}
+
+// failing returns a call failing with the codes in order, then succeeding.
+func failing(calls *int, seq ...codes.Code) func(context.Context) error {
+	return func(context.Context) error {
+		*calls++
+		if *calls <= len(seq) {
+			return status.Error(seq[*calls-1], "failed")
+		}
+		return nil
+	}
+}
+
+func TestCallRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		codes     []codes.Code
+		retries   int
+		wantCode  codes.Code
+		wantCalls int
+		wantState string
+	}{
+		{"success", nil, 2, codes.OK, 1, CircuitClosed},
+		{"unavailable then success", []codes.Code{codes.Unavailable, codes.Unavailable}, 2, codes.OK, 3, CircuitClosed},
+		{"retries exhausted", []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable}, 2, codes.Unavailable, 3, CircuitOpen},
+		{"deadline not retried", []codes.Code{codes.DeadlineExceeded}, 2, codes.DeadlineExceeded, 1, CircuitClosed},
+		{"invalid argument answered", []codes.Code{codes.InvalidArgument}, 2, codes.InvalidArgument, 1, CircuitClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testSentimentClient(tt.retries, 3)
+			calls := 0
+			err := c.call(context.Background(), failing(&calls, tt.codes...))
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("err = %v, want %s", err, tt.wantCode)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("%d calls, want %d", calls, tt.wantCalls)
+			}
+			if c.BreakerState() != tt.wantState {
+				t.Errorf("breaker %s, want %s", c.BreakerState(), tt.wantState)
+			}
+		})
+	}
+}
+
+func TestCallAnswerResetsFailures(t *testing.T) {
+	c := testSentimentClient(0, 2)
+	calls := 0
+	c.call(context.Background(), failing(&calls, codes.Unavailable))
+	calls = 0
+	c.call(context.Background(), failing(&calls, codes.NotFound))
+	calls = 0
+	c.call(context.Background(), failing(&calls, codes.Unavailable))
+	if c.BreakerState() != CircuitClosed {
+		t.Errorf("breaker %s, want the answer between two failures to reset the count", c.BreakerState())
+	}
+}
+
+func TestCallOpenCircuit(t *testing.T) {
+	c := testSentimentClient(0, 1)
+	calls := 0
+	c.call(context.Background(), failing(&calls, codes.Unavailable))
+	if err := c.call(context.Background(), failing(&calls)); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("err = %v, want ErrCircuitOpen", err)
+	}
+	if calls != 1 || c.Available() {
+		t.Errorf("%d calls, available %v: the open breaker let a call through", calls, c.Available())
+	}
+}
+
+func TestCallCanceledByCaller(t *testing.T) {
+	c := testSentimentClient(2, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := c.call(ctx, func(ctx context.Context) error {
+		calls++
+		cancel()
+		return status.FromContextError(ctx.Err()).Err()
+	})
+	if status.Code(err) != codes.Canceled || calls != 1 {
+		t.Errorf("err = %v after %d calls, want Canceled without retries", err, calls)
+	}
+	if c.BreakerState() != CircuitClosed {
+		t.Errorf("breaker %s after a canceled call", c.BreakerState())
+	}
+
+	// the caller's deadline is not a failure either, nor a success of a probe
+	c.breaker.Failure()
+	c.breaker.Cooldown = 0
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	c.call(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return status.FromContextError(ctx.Err()).Err()
+	})
+	if c.BreakerState() != CircuitHalfOpen || !c.breaker.Allow() {
+		t.Errorf("breaker %s, want the probe released", c.BreakerState())
+	}
+}
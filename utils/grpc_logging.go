@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"context"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"golang-restapi/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var requestIDMetadataKey = strings.ToLower(RequestIDHeader)
+
+// RequestIDClientInterceptor sends the request ID of the context as
+// x-request-id metadata.
+func RequestIDClientInterceptor(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if id := RequestID(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, id)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// incomingRequestID takes x-request-id from the metadata or generates one.
+func incomingRequestID(ctx context.Context) context.Context {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(requestIDMetadataKey); len(v) > 0 {
+			id = v[0]
+		}
+	}
+	if !ValidRequestID(id) {
+		id = NewRequestID()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, id))
+	return WithRequestID(ctx, id)
+}
+
+func logRPC(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+	}
+	slog.LogAttrs(ctx, level, "rpc",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+	)
+}
+
+// LoggingUnaryServerInterceptor gives every call a request ID and logs it.
+func LoggingUnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	ctx = incomingRequestID(ctx)
+	resp, err := handler(ctx, req)
+	logRPC(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+// LoggingStreamServerInterceptor is LoggingUnaryServerInterceptor for streams.
+func LoggingStreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx := incomingRequestID(ss.Context())
+	err := handler(srv, &requestIDStream{ServerStream: ss, ctx: ctx})
+	logRPC(ctx, info.FullMethod, start, err)
+	return err
+}
+
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
+
+// MetricsClientInterceptor records the duration and code of every call.
+func MetricsClientInterceptor(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	metrics.SentimentCallDuration.
+		WithLabelValues(path.Base(method), status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+	return err
+}
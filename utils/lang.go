@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLang is the analyzer language code of the Hungarian sources.
+const DefaultLang = "hun"
+
+// tsConfigs maps the analyzer language codes (see sentiment.proto) to the
+// text search configuration feeds.search_vector is built with.
+var tsConfigs = map[string]string{
+	"hun": "public.hun_unaccent",
+	"eng": "pg_catalog.english",
+	"dan": "pg_catalog.danish",
+}
+
+// ParseLang validates a lang query value; empty means DefaultLang.
+func ParseLang(lang string) (string, error) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		return DefaultLang, nil
+	}
+	if _, ok := tsConfigs[lang]; !ok {
+		return "", fmt.Errorf("unsupported lang %q", lang)
+	}
+	return lang, nil
+}
+
+// TSConfig returns the text search configuration of a language.
+func TSConfig(lang string) string {
+	if cfg, ok := tsConfigs[lang]; ok {
+		return cfg
+	}
+	return tsConfigs[DefaultLang]
+}
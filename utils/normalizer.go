@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Normalizer maps a surface form to the form words are counted by.
+type Normalizer interface {
+	Normalize(word string) string
+}
+
+// Normalization modes accepted by the word endpoints (normalize=...).
+const (
+	NormalizeNone  = "none"
+	NormalizeStem  = "stem"
+	NormalizeLemma = "lemma"
+)
+
+// NoopNormalizer keeps words as they are.
+type NoopNormalizer struct{}
+
+func (NoopNormalizer) Normalize(word string) string { return word }
+
+// HungarianStemmer is a rule-based suffix stripper for Hungarian.
+// It is aggressive on purpose: "Orbánnak", "Orbánt" and "Orbánnal" all become "orbán".
+type HungarianStemmer struct{}
+
+// minStemLen is the shortest stem (in runes) the stripper may leave behind.
+const minStemLen = 3
+
+// huSuffixes are tried longest first, in two passes: case endings, then
+// possessive/plural markers ("házainkban" -> "házaink" -> "ház").
+var huSuffixes = [][]string{
+	{
+		"ként", "ból", "ből", "ról", "ről", "tól", "től", "nak", "nek", "ban", "ben",
+		"hoz", "hez", "höz", "val", "vel", "nál", "nél", "kor", "ért", "ba", "be",
+		"ra", "re", "on", "en", "ön", "ig", "at", "et", "ot", "öt", "t",
+	},
+	{
+		"jaink", "jeink", "aink", "eink", "juk", "jük", "unk", "ünk", "ja", "je",
+		"ai", "ei", "ok", "ek", "ök", "ak", "uk", "ük", "k", "a", "e", "i",
+	},
+}
+
+// huVowelShortening undoes the lengthening of a final vowel before a suffix
+// ("almát" -> "almá" -> "alma"), so the bare "a"/"e" strip that follows
+// gives inflected and base forms the same stem ("alm").
+var huVowelShortening = map[string]string{"á": "a", "é": "e"}
+
+func (HungarianStemmer) Normalize(word string) string {
+	w := strings.ToLower(strings.TrimSpace(word))
+	for _, pass := range huSuffixes {
+		w, _ = shortenFinalVowel(w)
+		w = stripSuffix(w, pass)
+	}
+	// lengthened before a possessive or plural marker: "almái" -> "almá"
+	if short, ok := shortenFinalVowel(w); ok {
+		w = stripSuffix(short, []string{"a", "e"})
+	}
+	return w
+}
+
+func shortenFinalVowel(w string) (string, bool) {
+	last, size := utf8.DecodeLastRuneInString(w)
+	if size == 0 || utf8.RuneCountInString(w) <= minStemLen {
+		return w, false
+	}
+	short, ok := huVowelShortening[string(last)]
+	if !ok {
+		return w, false
+	}
+	return w[:len(w)-size] + short, true
+}
+
+// stripSuffix removes the first matching suffix, also handling the assimilated
+// "-val/-vel" ("Orbánnal" -> "Orbán").
+func stripSuffix(w string, suffixes []string) string {
+	for _, suf := range []string{"al", "el"} {
+		if base, ok := strings.CutSuffix(w, suf); ok {
+			r := []rune(base)
+			if n := len(r); n > minStemLen && r[n-1] == r[n-2] && !isHuVowel(r[n-1]) {
+				return string(r[:n-1])
+			}
+		}
+	}
+	for _, suf := range suffixes {
+		if base, ok := strings.CutSuffix(w, suf); ok && utf8.RuneCountInString(base) >= minStemLen {
+			return base
+		}
+	}
+	return w
+}
+
+func isHuVowel(r rune) bool {
+	return strings.ContainsRune("aáeéiíoóöőuúüű", r)
+}
+
+// DictionaryLemmatizer looks words up in a form -> lemma dictionary and
+// falls back to another Normalizer for unknown forms.
+type DictionaryLemmatizer struct {
+	lemmas   map[string]string
+	fallback Normalizer
+}
+
+// NewDictionaryLemmatizer loads a tab separated "form<TAB>lemma" file.
+// Empty lines and lines starting with # are skipped.
+func NewDictionaryLemmatizer(path string, fallback Normalizer) (*DictionaryLemmatizer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("NewDictionaryLemmatizer: open error: %w", err)
+	}
+	defer f.Close()
+
+	l := &DictionaryLemmatizer{lemmas: make(map[string]string), fallback: fallback}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		form, lemma, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		l.lemmas[strings.ToLower(strings.TrimSpace(form))] = strings.ToLower(strings.TrimSpace(lemma))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("NewDictionaryLemmatizer: read error: %w", err)
+	}
+	return l, nil
+}
+
+func (l *DictionaryLemmatizer) Normalize(word string) string {
+	if lemma, ok := l.lemmas[strings.ToLower(strings.TrimSpace(word))]; ok {
+		return lemma
+	}
+	if l.fallback != nil {
+		return l.fallback.Normalize(word)
+	}
+	return word
+}
+
+var (
+	lemmatizerMu sync.RWMutex
+	lemmatizer   Normalizer
+)
+
+// InitLemmatizer loads the lemma dictionary used by normalize=lemma.
+// Unknown forms fall back to the HungarianStemmer.
+func InitLemmatizer(path string) error {
+	l, err := NewDictionaryLemmatizer(path, HungarianStemmer{})
+	if err != nil {
+		return err
+	}
+	lemmatizerMu.Lock()
+	lemmatizer = l
+	lemmatizerMu.Unlock()
+	return nil
+}
+
+// NormalizerFor returns the Normalizer for a normalize=none|stem|lemma value.
+// Stemming and lemmatization are only available for Hungarian.
+func NormalizerFor(mode, lang string) (Normalizer, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode != "" && mode != NormalizeNone && lang != DefaultLang {
+		return nil, fmt.Errorf("normalize=%s is not available for lang %q", mode, lang)
+	}
+
+	switch mode {
+	case "", NormalizeNone:
+		return NoopNormalizer{}, nil
+	case NormalizeStem:
+		return HungarianStemmer{}, nil
+	case NormalizeLemma:
+		lemmatizerMu.RLock()
+		defer lemmatizerMu.RUnlock()
+		if lemmatizer == nil {
+			return nil, fmt.Errorf("lemma normalization is not configured")
+		}
+		return lemmatizer, nil
+	default:
+		return nil, fmt.Errorf("unknown normalize mode %q", mode)
+	}
+}
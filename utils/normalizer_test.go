@@ -0,0 +1,46 @@
+package utils
+
+import "testing"
+
+func TestHungarianStemmerSameStem(t *testing.T) {
+	tests := []struct {
+		base  string
+		forms []string
+	}{
+		{"Ukrajna", []string{"Ukrajnát", "Ukrajnában", "Ukrajnának", "Ukrajnáról"}},
+		{"Európa", []string{"Európában", "Európát", "Európával"}},
+		{"alma", []string{"almát", "almák", "almái", "almában"}},
+		{"béke", []string{"békét", "békéről", "békével"}},
+		{"Orbán", []string{"Orbánnak", "Orbánt", "Orbánnal", "Orbánról"}},
+		{"ház", []string{"házban", "házainkban", "házak"}},
+		{"tragédia", []string{"tragédiát", "tragédiában"}},
+		{"katasztrófa", []string{"katasztrófát", "katasztrófáról"}},
+		{"halál", []string{"halálát", "halálról", "halálban"}},
+	}
+	var s HungarianStemmer
+	for _, tt := range tests {
+		want := s.Normalize(tt.base)
+		for _, form := range tt.forms {
+			if got := s.Normalize(form); got != want {
+				t.Errorf("Normalize(%q) = %q, Normalize(%q) = %q; want the same stem", form, got, tt.base, want)
+			}
+		}
+	}
+}
+
+func TestHungarianStemmerStems(t *testing.T) {
+	tests := map[string]string{
+		"Ukrajnát":   "ukrajn",
+		"Európában":  "európ",
+		"Orbánnal":   "orbán",
+		"házainkban": "ház",
+		"kép":        "kép",
+		"ma":         "ma",
+	}
+	var s HungarianStemmer
+	for in, want := range tests {
+		if got := s.Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -2,62 +2,121 @@
 package utils
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
 	"golang-restapi/models"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
-// RSS struct for parsing Google News RSS
+// RSS struct for parsing RSS 2.0 feeds (Google News included)
 type RSS struct {
 	Channel struct {
 		Items []struct {
-			Title   string `xml:"title"`
-			Link    string `xml:"link"`
-			PubDate string `xml:"pubDate"`
-			Source  string `xml:"source"`
+			Title       string   `xml:"title"`
+			Link        string   `xml:"link"`
+			GUID        string   `xml:"guid"`
+			Description string   `xml:"description"`
+			Categories  []string `xml:"category"`
+			PubDate     string   `xml:"pubDate"`
+			Source      string   `xml:"source"`
 		} `xml:"item"`
 	} `xml:"channel"`
 }
 
-func GetGoogleNews(q, period, lang, country string) ([]models.GNewsItem, error) {
+// ParseRSS parses an RSS 2.0 document. Items without a parsable pubDate
+// get a zero Published.
+func ParseRSS(body []byte) ([]FeedItem, error) {
+	var rss RSS
+	if err := decodeXML(body, &rss); err != nil {
+		return nil, fmt.Errorf("ParseRSS: parse error: %w", err)
+	}
 
-	escapedQ := url.QueryEscape(q)
-	url := fmt.Sprintf("https://news.google.com/rss/search?q=%s&hl=%s&gl=%s&ceid=%s:%s",
-		escapedQ, lang, country, strings.ToUpper(country), lang)
+	items := make([]FeedItem, 0, len(rss.Channel.Items))
+	for _, item := range rss.Channel.Items {
+		published, _ := ParseFeedDate(item.PubDate)
+		items = append(items, FeedItem{
+			Title:       strings.TrimSpace(item.Title),
+			Link:        strings.TrimSpace(item.Link),
+			GUID:        strings.TrimSpace(item.GUID),
+			Description: strings.TrimSpace(item.Description),
+			Categories:  item.Categories,
+			Source:      strings.TrimSpace(item.Source),
+			Published:   published,
+			PubDate:     strings.TrimSpace(item.PubDate),
+		})
+	}
+	return items, nil
+}
+
+// FetchFeed downloads and parses a feed (RSS, Atom or JSON Feed).
+func FetchFeed(ctx context.Context, client *http.Client, feedURL string) ([]FeedItem, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FetchFeed: request error: %w", err)
+	}
 
-	resp, err := http.Get(url)
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("GetGoogleNews: http error: %w", err)
+		return nil, fmt.Errorf("FetchFeed: http error: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("FetchFeed: unexpected status %d from %s", resp.StatusCode, feedURL)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("GetGoogleNews: read error: %w", err)
+		return nil, fmt.Errorf("FetchFeed: read error: %w", err)
 	}
 
-	var rss RSS
-	if err := xml.Unmarshal(body, &rss); err != nil {
-		return nil, fmt.Errorf("GetGoogleNews: parse error: %w", err)
+	return ParseFeed(body)
+}
+
+// googleNewsClient bounds a Google News search, which a request waits for.
+var googleNewsClient = &http.Client{Timeout: 10 * time.Second}
+
+func GetGoogleNews(ctx context.Context, q, period, lang, country string) ([]models.GNewsItem, error) {
+
+	if period != "" {
+		q += " when:" + period // Google News search operator, e.g. when:7d
 	}
+	escapedQ := url.QueryEscape(q)
+	url := fmt.Sprintf("https://news.google.com/rss/search?q=%s&hl=%s&gl=%s&ceid=%s:%s",
+		escapedQ, lang, country, strings.ToUpper(country), lang)
 
-	feeds := make([]models.GNewsItem, 0, len(rss.Channel.Items))
-	for _, item := range rss.Channel.Items {
+	items, err := FetchFeed(ctx, googleNewsClient, url)
+	if err != nil {
+		return nil, fmt.Errorf("GetGoogleNews: %w", err)
+	}
+
+	return gnewsItems(items), nil
+}
+
+// gnewsItems strips the " - Source" suffix Google News adds to titles.
+func gnewsItems(items []FeedItem) []models.GNewsItem {
+	feeds := make([]models.GNewsItem, 0, len(items))
+	for _, item := range items {
 		title := item.Title
 		source := item.Source
 		if strings.HasSuffix(title, " - "+source) {
 			title = strings.TrimSuffix(title, " - "+source)
 		}
+		// keep the date as written when it did not parse
+		published := item.PubDate
+		if !item.Published.IsZero() {
+			published = item.Published.Format(time.RFC1123)
+		}
 		feeds = append(feeds, models.GNewsItem{
 			Title:     title,
-			Published: item.PubDate,
+			Link:      item.Link,
+			Published: published,
 			Source:    source,
 		})
 	}
-
-	return feeds, nil
+	return feeds
 }
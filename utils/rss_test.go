@@ -0,0 +1,24 @@
+package utils
+
+import "testing"
+
+func TestGNewsItemsPublished(t *testing.T) {
+	items, err := ParseRSS([]byte(`<rss version="2.0"><channel>
+<item><title>Valid date - Index</title><link>https://example.com/1</link><source>Index</source><pubDate>Tue, 14 Oct 2025 08:30:00 GMT</pubDate></item>
+<item><title>Broken date - Telex</title><link>https://example.com/2</link><source>Telex</source><pubDate>tegnap délután</pubDate></item>
+</channel></rss>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := gnewsItems(items)
+	if len(got) != 2 {
+		t.Fatalf("got %d items, want 2", len(got))
+	}
+	if got[0].Title != "Valid date" || got[0].Published != "Tue, 14 Oct 2025 08:30:00 UTC" {
+		t.Errorf("item 0 = %+v", got[0])
+	}
+	if got[1].Title != "Broken date" || got[1].Published != "tegnap délután" {
+		t.Errorf("item 1 = %+v, want the raw pubDate", got[1])
+	}
+}
@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"strings"
+
+	"golang-restapi/sentimentpb"
+)
+
+// Sentiment labels, as stored in feed_sentiments.sentiment_key.
+const (
+	SentimentPositive = "positive"
+	SentimentNegative = "negative"
+	SentimentNeutral  = "neutral"
+	SentimentCompound = "compound"
+)
+
+// Sentiment backends selectable by SENTIMENT_BACKEND.
+const (
+	SentimentBackendGRPC    = "grpc"
+	SentimentBackendLexicon = "lexicon"
+	SentimentBackendFake    = "fake"
+)
+
+// SentimentInput is a text to analyze in a language ("hun", "eng", "dan").
+type SentimentInput struct {
+	Text string
+	Lang string
+}
+
+// SentimentResult is the score of one text.
+type SentimentResult struct {
+	Text   string
+	Key    string             // dominant label
+	Value  float64            // score of the dominant label
+	Scores map[string]float64 // positive, negative, neutral and compound
+}
+
+// Compound returns the compound score (-1..1).
+func (r SentimentResult) Compound() float64 {
+	return r.Scores[SentimentCompound]
+}
+
+// SentimentAnalyzer scores texts.
+type SentimentAnalyzer interface {
+	Analyze(ctx context.Context, in SentimentInput) (SentimentResult, error)
+	// BatchAnalyze returns one result per input, in order.
+	BatchAnalyze(ctx context.Context, in []SentimentInput) ([]SentimentResult, error)
+}
+
+// DefaultAnalyzer is the analyzer of the ad-hoc endpoints (set in main).
+var DefaultAnalyzer SentimentAnalyzer
+
+// NewSentimentAnalyzer returns the analyzer of a SENTIMENT_BACKEND value;
+// client is only used by the grpc backend.
+func NewSentimentAnalyzer(backend string, client sentimentpb.SentimentServiceClient) (SentimentAnalyzer, error) {
+	switch strings.ToLower(backend) {
+	case "", SentimentBackendGRPC:
+		return GRPCAnalyzer{Client: client}, nil
+	case SentimentBackendLexicon:
+		return NewLexiconAnalyzer(), nil
+	case SentimentBackendFake:
+		return FakeAnalyzer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown sentiment backend %q", backend)
+	}
+}
+
+// GRPCAnalyzer calls the external SentimentService.
+type GRPCAnalyzer struct {
+	Client sentimentpb.SentimentServiceClient
+}
+
+func (a GRPCAnalyzer) Analyze(ctx context.Context, in SentimentInput) (SentimentResult, error) {
+	resp, err := a.Client.Analyze(ctx, &sentimentpb.AnalyzeRequest{Text: in.Text, Language: in.Lang})
+	if err != nil {
+		return SentimentResult{}, fmt.Errorf("Analyze: %w", err)
+	}
+	return resultFromPB(in.Text, resp), nil
+}
+
+func (a GRPCAnalyzer) BatchAnalyze(ctx context.Context, in []SentimentInput) ([]SentimentResult, error) {
+	req := &sentimentpb.BatchAnalyzeRequest{Items: make([]*sentimentpb.AnalyzeRequest, len(in))}
+	for i, item := range in {
+		req.Items[i] = &sentimentpb.AnalyzeRequest{Text: item.Text, Language: item.Lang}
+	}
+
+	resp, err := a.Client.BatchAnalyze(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("BatchAnalyze: %w", err)
+	}
+	if len(resp.GetResults()) != len(in) {
+		return nil, fmt.Errorf("BatchAnalyze: got %d results for %d texts", len(resp.GetResults()), len(in))
+	}
+
+	out := make([]SentimentResult, len(in))
+	for i, r := range resp.GetResults() {
+		out[i] = resultFromPB(in[i].Text, r)
+	}
+	return out, nil
+}
+
+// Available reports whether the client lets calls through (see SentimentClient).
+func (a GRPCAnalyzer) Available() bool {
+	if c, ok := a.Client.(interface{ Available() bool }); ok {
+		return c.Available()
+	}
+	return true
+}
+
+// Check checks the health of the SentimentService (see SentimentClient.Check).
+func (a GRPCAnalyzer) Check(ctx context.Context) error {
+	if c, ok := a.Client.(interface{ Check(context.Context) error }); ok {
+		return c.Check(ctx)
+	}
+	return nil
+}
+
+func resultFromPB(text string, r *sentimentpb.AnalyzeResponse) SentimentResult {
+	return SentimentResult{
+		Text:   text,
+		Key:    strings.ToLower(r.GetSentimentKey()),
+		Value:  r.GetSentimentValue(),
+		Scores: r.GetSentiments(),
+	}
+}
+
+// FallbackAnalyzer uses Fallback whenever Primary fails. The two score
+// differently, so only use it where results are not stored.
+type FallbackAnalyzer struct {
+	Primary  SentimentAnalyzer
+	Fallback SentimentAnalyzer
+}
+
+func (a FallbackAnalyzer) Analyze(ctx context.Context, in SentimentInput) (SentimentResult, error) {
+	r, err := a.Primary.Analyze(ctx, in)
+	if err == nil {
+		return r, nil
+	}
+	slog.WarnContext(ctx, "primary analyzer failed, falling back", slog.Any("error", err))
+	return a.Fallback.Analyze(ctx, in)
+}
+
+func (a FallbackAnalyzer) BatchAnalyze(ctx context.Context, in []SentimentInput) ([]SentimentResult, error) {
+	r, err := a.Primary.BatchAnalyze(ctx, in)
+	if err == nil {
+		return r, nil
+	}
+	slog.WarnContext(ctx, "primary analyzer failed, falling back", slog.Any("error", err))
+	return a.Fallback.BatchAnalyze(ctx, in)
+}
+
+// FakeAnalyzer derives a stable score from a hash of the text, for tests
+// and local development.
+type FakeAnalyzer struct{}
+
+func (FakeAnalyzer) Analyze(_ context.Context, in SentimentInput) (SentimentResult, error) {
+	h := fnv.New32a()
+	h.Write([]byte(in.Lang + "\x00" + in.Text))
+	compound := float64(h.Sum32()%2001)/1000 - 1 // -1..1 in 0.001 steps
+	return scoreResult(in.Text, compound), nil
+}
+
+func (a FakeAnalyzer) BatchAnalyze(ctx context.Context, in []SentimentInput) ([]SentimentResult, error) {
+	out := make([]SentimentResult, len(in))
+	for i, item := range in {
+		out[i], _ = a.Analyze(ctx, item)
+	}
+	return out, nil
+}
+
+// scoreResult spreads a compound score over the three labels.
+func scoreResult(text string, compound float64) SentimentResult {
+	pos, neg := max(compound, 0), max(-compound, 0)
+	return newSentimentResult(text, pos, neg, 1-pos-neg, compound)
+}
+
+// newSentimentResult picks the dominant label by the usual ±0.05 compound thresholds.
+func newSentimentResult(text string, pos, neg, neu, compound float64) SentimentResult {
+	r := SentimentResult{
+		Text: text,
+		Scores: map[string]float64{
+			SentimentPositive: pos,
+			SentimentNegative: neg,
+			SentimentNeutral:  neu,
+			SentimentCompound: compound,
+		},
+	}
+	switch {
+	case compound >= 0.05:
+		r.Key, r.Value = SentimentPositive, pos
+	case compound <= -0.05:
+		r.Key, r.Value = SentimentNegative, neg
+	default:
+		r.Key, r.Value = SentimentNeutral, neu
+	}
+	return r
+}
@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SentimentCacheStore is a second, shared cache level (the sentiment_cache table).
+type SentimentCacheStore interface {
+	GetSentiments(ctx context.Context, keys []string) (map[string]SentimentResult, error)
+	PutSentiments(ctx context.Context, results map[string]SentimentResult) error
+}
+
+// SentimentCacheConfig configures a CachedAnalyzer.
+type SentimentCacheConfig struct {
+	Size         int           // entries kept in memory
+	TTL          time.Duration // age after which an entry is analyzed again
+	ModelVersion string        // part of the key: a new model version starts from scratch
+	Store        SentimentCacheStore
+}
+
+// SentimentCacheStats are the counters of a CachedAnalyzer.
+type SentimentCacheStats struct {
+	ModelVersion string `json:"model_version"`
+	Entries      int    `json:"entries"`
+	Hits         int64  `json:"hits"`
+	StoreHits    int64  `json:"store_hits"`
+	Misses       int64  `json:"misses"`
+	Evictions    int64  `json:"evictions"`
+}
+
+type sentimentCacheEntry struct {
+	key    string
+	result SentimentResult
+	stored time.Time
+}
+
+// CachedAnalyzer keeps the results of another analyzer, keyed by a hash of
+// (model version, language, text), so repeated titles are analyzed once.
+type CachedAnalyzer struct {
+	next SentimentAnalyzer
+	cfg  SentimentCacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front = most recently used
+
+	hits, storeHits, misses, evictions atomic.Int64
+}
+
+func NewCachedAnalyzer(next SentimentAnalyzer, cfg SentimentCacheConfig) *CachedAnalyzer {
+	return &CachedAnalyzer{
+		next:    next,
+		cfg:     cfg,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// SentimentCacheKey is the content hash of a text for a model version.
+func SentimentCacheKey(modelVersion string, in SentimentInput) string {
+	text := strings.Join(strings.Fields(in.Text), " ")
+	sum := sha256.Sum256([]byte(modelVersion + "\x00" + in.Lang + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *CachedAnalyzer) Analyze(ctx context.Context, in SentimentInput) (SentimentResult, error) {
+	out, err := a.BatchAnalyze(ctx, []SentimentInput{in})
+	if err != nil {
+		return SentimentResult{}, err
+	}
+	return out[0], nil
+}
+
+// BatchAnalyze serves what it can from memory, then from the store, and
+// sends only the remaining texts (each once) to the wrapped analyzer.
+func (a *CachedAnalyzer) BatchAnalyze(ctx context.Context, in []SentimentInput) ([]SentimentResult, error) {
+	out := make([]SentimentResult, len(in))
+	keys := make([]string, len(in))
+	pending := map[string][]int{} // key -> positions in `in`
+
+	for i, item := range in {
+		keys[i] = SentimentCacheKey(a.cfg.ModelVersion, item)
+		if r, ok := a.get(keys[i]); ok {
+			a.hits.Add(1)
+			out[i] = withText(r, item.Text)
+			continue
+		}
+		pending[keys[i]] = append(pending[keys[i]], i)
+	}
+
+	if len(pending) > 0 && a.cfg.Store != nil {
+		found, err := a.cfg.Store.GetSentiments(ctx, mapKeys(pending))
+		if err != nil {
+			slog.WarnContext(ctx, "sentiment cache store lookup failed", slog.Any("error", err))
+		}
+		for key, r := range found {
+			a.put(key, r)
+			for _, i := range pending[key] {
+				a.storeHits.Add(1)
+				out[i] = withText(r, in[i].Text)
+			}
+			delete(pending, key)
+		}
+	}
+	if len(pending) == 0 {
+		return out, nil
+	}
+
+	// one call for the misses, duplicates included once
+	misses := make([]SentimentInput, 0, len(pending))
+	missKeys := make([]string, 0, len(pending))
+	for key, idx := range pending {
+		misses = append(misses, in[idx[0]])
+		missKeys = append(missKeys, key)
+	}
+	a.misses.Add(int64(len(misses)))
+
+	results, err := a.next.BatchAnalyze(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+
+	fresh := make(map[string]SentimentResult, len(results))
+	for j, r := range results {
+		key := missKeys[j]
+		a.put(key, r)
+		fresh[key] = r
+		for _, i := range pending[key] {
+			out[i] = withText(r, in[i].Text)
+		}
+	}
+	if a.cfg.Store != nil {
+		if err := a.cfg.Store.PutSentiments(ctx, fresh); err != nil {
+			slog.WarnContext(ctx, "sentiment cache store write failed", slog.Any("error", err))
+		}
+	}
+	return out, nil
+}
+
+// Available passes the availability of the wrapped analyzer through.
+func (a *CachedAnalyzer) Available() bool {
+	if n, ok := a.next.(interface{ Available() bool }); ok {
+		return n.Available()
+	}
+	return true
+}
+
+// Check passes the health check of the wrapped analyzer through.
+func (a *CachedAnalyzer) Check(ctx context.Context) error {
+	if n, ok := a.next.(interface{ Check(context.Context) error }); ok {
+		return n.Check(ctx)
+	}
+	return nil
+}
+
+// Stats returns the cache counters.
+func (a *CachedAnalyzer) Stats() SentimentCacheStats {
+	a.mu.Lock()
+	entries := a.lru.Len()
+	a.mu.Unlock()
+	return SentimentCacheStats{
+		ModelVersion: a.cfg.ModelVersion,
+		Entries:      entries,
+		Hits:         a.hits.Load(),
+		StoreHits:    a.storeHits.Load(),
+		Misses:       a.misses.Load(),
+		Evictions:    a.evictions.Load(),
+	}
+}
+
+func (a *CachedAnalyzer) get(key string) (SentimentResult, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	el, ok := a.entries[key]
+	if !ok {
+		return SentimentResult{}, false
+	}
+	e := el.Value.(*sentimentCacheEntry)
+	if a.cfg.TTL > 0 && time.Since(e.stored) > a.cfg.TTL {
+		a.lru.Remove(el)
+		delete(a.entries, key)
+		return SentimentResult{}, false
+	}
+	a.lru.MoveToFront(el)
+	return e.result, true
+}
+
+func (a *CachedAnalyzer) put(key string, r SentimentResult) {
+	if a.cfg.Size <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if el, ok := a.entries[key]; ok {
+		el.Value = &sentimentCacheEntry{key: key, result: r, stored: time.Now()}
+		a.lru.MoveToFront(el)
+		return
+	}
+	a.entries[key] = a.lru.PushFront(&sentimentCacheEntry{key: key, result: r, stored: time.Now()})
+	for a.lru.Len() > a.cfg.Size {
+		oldest := a.lru.Back()
+		a.lru.Remove(oldest)
+		delete(a.entries, oldest.Value.(*sentimentCacheEntry).key)
+		a.evictions.Add(1)
+	}
+}
+
+func withText(r SentimentResult, text string) SentimentResult {
+	r.Text = text
+	return r
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// huLexicon holds the valence (-4..4) of common Hungarian news words, in
+// dictionary form. Surface forms are matched through the HungarianStemmer.
+var huLexicon = map[string]float64{
+	// positive
+	"jó": 1.9, "kiváló": 3.0, "nagyszerű": 3.1, "remek": 2.9, "csodás": 3.0, "fantasztikus": 3.2,
+	"legjobb": 3.0, "szép": 2.0, "siker": 2.3, "sikeres": 2.3, "sikerül": 2.0, "győz": 2.2,
+	"győzelem": 2.7, "nyer": 2.0, "javul": 1.8, "javulás": 1.8, "fejlődés": 1.6, "növekedés": 1.5,
+	"bővül": 1.2, "öröm": 2.6, "boldog": 2.7, "ünnep": 1.9, "béke": 2.2, "biztonság": 1.5,
+	"segít": 1.6, "segítség": 1.7, "támogat": 1.3, "támogatás": 1.3, "megoldás": 1.4, "rekord": 1.2,
+	"díj": 1.5, "elismerés": 2.0, "gratulál": 2.2, "hős": 2.1, "megment": 2.3, "szeret": 2.5,
+	"szerelem": 2.6, "egészség": 1.5, "gyógyul": 1.8, "hatékony": 1.6, "stabil": 1.1,
+	"megállapodás": 1.3, "érdekes": 1.2, "emelkedik": 0.9,
+
+	// negative
+	"rossz": -2.1, "szörnyű": -3.1, "tragédia": -3.3, "tragikus": -3.2, "halál": -3.0, "meghal": -2.9,
+	"halott": -2.8, "baleset": -2.5, "háború": -3.0, "támadás": -2.6, "támad": -2.3, "bűn": -2.5,
+	"bűncselekmény": -2.7, "gyilkos": -3.3, "gyilkosság": -3.4, "botrány": -2.6, "csalás": -2.6,
+	"korrupció": -2.9, "válság": -2.5, "infláció": -1.6, "drága": -1.2, "drágul": -1.6,
+	"csökken": -1.0, "zuhan": -2.0, "veszély": -2.2, "veszélyes": -2.3, "félelem": -2.3,
+	"harag": -2.3, "dühös": -2.4, "tiltakozás": -1.2, "sérült": -2.1, "megsérül": -2.1,
+	"tűz": -1.8, "árvíz": -2.4, "vihar": -1.6, "katasztrófa": -3.2, "hiba": -1.5, "kudarc": -2.4,
+	"bukás": -2.2, "veszít": -1.9, "vereség": -2.2, "aggódik": -1.6, "aggodalom": -1.7,
+	"probléma": -1.7, "fenyeget": -2.3, "fenyegetés": -2.4, "erőszak": -3.0, "áldozat": -2.3,
+	"letartóztat": -1.8, "börtön": -2.2, "vád": -1.6, "hazugság": -2.4, "hazudik": -2.4,
+	"betegség": -2.0, "járvány": -2.3, "hiány": -1.5, "csőd": -2.8, "elbocsát": -2.0,
+	"sztrájk": -1.3, "szegénység": -2.3, "gyász": -2.6,
+}
+
+// huNegators flip the valence of the sentiment words following them.
+var huNegators = map[string]bool{
+	"nem": true, "sem": true, "se": true, "nincs": true, "nincsenek": true, "sincs": true, "soha": true,
+}
+
+// huBoosters strengthen (>0) or dampen (<0) the next sentiment word.
+var huBoosters = map[string]float64{
+	"nagyon": 0.293, "rendkívül": 0.293, "különösen": 0.293, "borzasztóan": 0.293,
+	"teljesen": 0.293, "igazán": 0.293, "túl": 0.293,
+	"kissé": -0.293, "kicsit": -0.293, "némileg": -0.293, "alig": -0.293,
+}
+
+const (
+	negationScalar = -0.74 // as in VADER
+	negationWindow = 3     // tokens a negator reaches
+	compoundAlpha  = 15    // normalisation constant of the compound score
+)
+
+// LexiconAnalyzer is an in-process, VADER-style Hungarian analyzer. Other
+// languages are scored neutral.
+type LexiconAnalyzer struct {
+	lexicon map[string]float64 // dictionary forms
+	stems   map[string]float64 // the same, stemmed
+	stemmer HungarianStemmer
+}
+
+func NewLexiconAnalyzer() *LexiconAnalyzer {
+	a := &LexiconAnalyzer{lexicon: huLexicon, stems: make(map[string]float64, len(huLexicon))}
+	for word, v := range huLexicon {
+		a.stems[a.stemmer.Normalize(word)] = v
+	}
+	return a
+}
+
+func (a *LexiconAnalyzer) valence(token string) (float64, bool) {
+	if v, ok := a.lexicon[token]; ok {
+		return v, true
+	}
+	v, ok := a.stems[a.stemmer.Normalize(token)]
+	return v, ok
+}
+
+func (a *LexiconAnalyzer) Analyze(_ context.Context, in SentimentInput) (SentimentResult, error) {
+	if in.Lang != "" && in.Lang != DefaultLang {
+		return scoreResult(in.Text, 0), nil
+	}
+
+	tokens := strings.FieldsFunc(strings.ToLower(in.Text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+
+	var sum, posSum, negSum float64
+	var neutral int
+	negatedUntil, boost := -1, 0.0
+	for i, tok := range tokens {
+		if huNegators[tok] {
+			negatedUntil = i + negationWindow
+			continue
+		}
+		if b, ok := huBoosters[tok]; ok {
+			boost = b
+			continue
+		}
+
+		v, ok := a.valence(tok)
+		if !ok {
+			neutral++
+			boost = 0
+			continue
+		}
+		if boost != 0 {
+			v += math.Copysign(1, v) * boost // away from zero, or towards it
+			boost = 0
+		}
+		if i <= negatedUntil {
+			v *= negationScalar
+		}
+
+		sum += v
+		if v > 0 {
+			posSum += v + 1
+		} else {
+			negSum += v - 1
+		}
+	}
+
+	compound := sum / math.Sqrt(sum*sum+compoundAlpha)
+	total := posSum + math.Abs(negSum) + float64(neutral)
+	if total == 0 {
+		return scoreResult(in.Text, 0), nil
+	}
+
+	return newSentimentResult(in.Text, posSum/total, math.Abs(negSum)/total, float64(neutral)/total, compound), nil
+}
+
+func (a *LexiconAnalyzer) BatchAnalyze(ctx context.Context, in []SentimentInput) ([]SentimentResult, error) {
+	out := make([]SentimentResult, len(in))
+	for i, item := range in {
+		out[i], _ = a.Analyze(ctx, item)
+	}
+	return out, nil
+}
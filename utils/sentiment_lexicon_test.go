@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestLexiconAnalyzerInflectedForms(t *testing.T) {
+	a := NewLexiconAnalyzer()
+	ctx := context.Background()
+	tests := []struct {
+		base  string
+		forms []string
+	}{
+		{"tragédia", []string{"tragédiát", "tragédiában", "tragédiáról"}},
+		{"katasztrófa", []string{"katasztrófát", "katasztrófához"}},
+		{"halál", []string{"halálát", "halálról"}},
+		{"béke", []string{"békét", "békéről"}},
+		{"győzelem", []string{"győzelemről"}},
+	}
+	for _, tt := range tests {
+		base, _ := a.Analyze(ctx, SentimentInput{Text: tt.base, Lang: DefaultLang})
+		if math.Abs(base.Compound()) < 0.3 {
+			t.Errorf("%q compound = %.3f, want a clear score", tt.base, base.Compound())
+		}
+		for _, form := range tt.forms {
+			got, _ := a.Analyze(ctx, SentimentInput{Text: form, Lang: DefaultLang})
+			if got.Compound() != base.Compound() || got.Key != base.Key {
+				t.Errorf("%q = %s %.3f, want %s %.3f like %q", form, got.Key, got.Compound(), base.Key, base.Compound(), tt.base)
+			}
+		}
+	}
+}
+
+func TestLexiconAnalyzer(t *testing.T) {
+	a := NewLexiconAnalyzer()
+	score := func(text, lang string) SentimentResult {
+		r, err := a.Analyze(context.Background(), SentimentInput{Text: text, Lang: lang})
+		if err != nil {
+			t.Fatalf("Analyze(%q): %v", text, err)
+		}
+		return r
+	}
+
+	if r := score("Súlyos baleset és tragédia a Balatonon", DefaultLang); r.Key != SentimentNegative || r.Compound() >= 0 {
+		t.Errorf("negative title = %s %.3f", r.Key, r.Compound())
+	}
+	if r := score("Kiváló győzelem, remek siker", DefaultLang); r.Key != SentimentPositive || r.Compound() <= 0 {
+		t.Errorf("positive title = %s %.3f", r.Key, r.Compound())
+	}
+	if r := score("A kormány ülést tartott", DefaultLang); r.Key != SentimentNeutral || r.Compound() != 0 {
+		t.Errorf("neutral title = %s %.3f", r.Key, r.Compound())
+	}
+
+	plain, negated := score("jó döntés", DefaultLang), score("nem jó döntés", DefaultLang)
+	if negated.Compound() >= 0 || plain.Compound() <= 0 {
+		t.Errorf("negation: %.3f -> %.3f, want the sign flipped", plain.Compound(), negated.Compound())
+	}
+	if boosted := score("nagyon jó döntés", DefaultLang); boosted.Compound() <= plain.Compound() {
+		t.Errorf("booster: %.3f -> %.3f, want stronger", plain.Compound(), boosted.Compound())
+	}
+	if damped := score("kissé jó döntés", DefaultLang); damped.Compound() >= plain.Compound() {
+		t.Errorf("dampener: %.3f -> %.3f, want weaker", plain.Compound(), damped.Compound())
+	}
+
+	if r := score("tragédia", "en"); r.Compound() != 0 {
+		t.Errorf("other languages compound = %.3f, want 0", r.Compound())
+	}
+}
+
+func TestFakeAnalyzer(t *testing.T) {
+	var a FakeAnalyzer
+	ctx := context.Background()
+	in := []SentimentInput{
+		{Text: "Első cím", Lang: "hu"},
+		{Text: "Második cím", Lang: "hu"},
+		{Text: "Első cím", Lang: "en"},
+	}
+
+	batch, err := a.BatchAnalyze(ctx, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != len(in) {
+		t.Fatalf("got %d results for %d inputs", len(batch), len(in))
+	}
+	for i, item := range in {
+		one, _ := a.Analyze(ctx, item)
+		if batch[i].Text != item.Text || batch[i].Compound() != one.Compound() || batch[i].Key != one.Key {
+			t.Errorf("BatchAnalyze[%d] = %+v, Analyze = %+v", i, batch[i], one)
+		}
+		if c := one.Compound(); c < -1 || c > 1 {
+			t.Errorf("compound %.3f out of -1..1", c)
+		}
+		again, _ := a.Analyze(ctx, item)
+		if again.Compound() != one.Compound() {
+			t.Errorf("%q scored %.3f then %.3f, want stable scores", item.Text, one.Compound(), again.Compound())
+		}
+	}
+	if batch[0].Compound() == batch[2].Compound() {
+		t.Errorf("the language is not part of the score")
+	}
+}
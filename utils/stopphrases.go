@@ -1,6 +0,0 @@
-package utils
-
-var StopPhrasessList = []string{
-	"friss hírek", "hírek forgalom", "reggeli pakk", "forgalom időjárás", "legfontosabb hírei",
-	"időjárás reggeli", "nap legfontosabb", "heti nyerőszámok", "2025. május",
-}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenizeTitle splits a title into lowercased words, the way they are
+// stored in feeds.words. masked is the same list without the names
+// (capitalised tokens that do not start a sentence), stored in feeds.words_masked.
+func TokenizeTitle(title string) (words, masked []string) {
+	sentenceStart := true
+	for _, raw := range strings.Fields(title) {
+		tok := strings.TrimFunc(raw, isTokenTrim)
+		if tok != "" {
+			lower := strings.ToLower(tok)
+			words = append(words, lower)
+			if sentenceStart || !isCapitalised(tok) {
+				masked = append(masked, lower)
+			}
+		}
+
+		last, _ := utf8.DecodeLastRuneInString(raw)
+		switch last {
+		case ':', '.', '!', '?':
+			sentenceStart = true
+		default:
+			sentenceStart = tok == "" && sentenceStart
+		}
+	}
+	return words, masked
+}
+
+// isTokenTrim trims punctuation and symbols around a word; inner hyphens stay ("EU-s").
+func isTokenTrim(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
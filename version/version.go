@@ -0,0 +1,40 @@
+// Package version holds the build information served at /version. Commit
+// and BuildTime are set at build time:
+//
+//	go build -ldflags "-X golang-restapi/version.Commit=$(git rev-parse HEAD) \
+//		-X golang-restapi/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"golang-restapi/models"
+)
+
+var (
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info returns the build information. Without ldflags it falls back to the
+// VCS stamp Go embeds when building inside a git checkout.
+func Info() models.BuildInfo {
+	info := models.BuildInfo{Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch {
+			case s.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = s.Value
+			case s.Key == "vcs.time" && info.BuildTime == "":
+				info.BuildTime = s.Value
+			case s.Key == "vcs.modified" && s.Value == "true":
+				info.Modified = true
+			}
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	return info
+}